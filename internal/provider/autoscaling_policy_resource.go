@@ -0,0 +1,368 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AutoscalingPolicyResource{}
+var _ resource.ResourceWithImportState = &AutoscalingPolicyResource{}
+var _ resource.ResourceWithModifyPlan = &AutoscalingPolicyResource{}
+var _ resource.ResourceWithValidateConfig = &AutoscalingPolicyResource{}
+
+// Metrics an autoscaling policy can track. Utilization metrics are
+// percentages.
+const (
+	autoscalingMetricCPU      = "cpu_utilization"
+	autoscalingMetricMemory   = "memory_utilization"
+	autoscalingMetricRequests = "requests_per_instance"
+)
+
+func NewAutoscalingPolicyResource() resource.Resource {
+	return &AutoscalingPolicyResource{}
+}
+
+// AutoscalingPolicyResource defines the resource implementation.
+type AutoscalingPolicyResource struct {
+	providerData *providerData
+}
+
+// AutoscalingPolicyResourceModel describes the resource data model.
+type AutoscalingPolicyResourceModel struct {
+	ID              types.Int64              `tfsdk:"id"`
+	InstanceGroupID types.Int64              `tfsdk:"instance_group_id"`
+	Region          types.String             `tfsdk:"region"`
+	Project         types.String             `tfsdk:"project"`
+	MinSize         types.Int64              `tfsdk:"min_size"`
+	MaxSize         types.Int64              `tfsdk:"max_size"`
+	CooldownSeconds types.Int64              `tfsdk:"cooldown_seconds"`
+	Targets         []AutoscalingTargetModel `tfsdk:"target"`
+}
+
+// AutoscalingTargetModel describes a target block.
+type AutoscalingTargetModel struct {
+	Metric types.String  `tfsdk:"metric"`
+	Value  types.Float64 `tfsdk:"value"`
+}
+
+func (r *AutoscalingPolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_autoscaling_policy"
+}
+
+func (r *AutoscalingPolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Scales a `fakecloud_instance_group` between a minimum and maximum size to keep metrics at their target. " +
+			"Omit `desired_size` from the instance group so Terraform keeps the size chosen by the autoscaler. " +
+			"Import with `<id>`, prefixed with `<region>/` outside the provider region, or `<project>/<region>/` outside the provider project.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Autoscaling policy identifier",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"instance_group_id": schema.Int64Attribute{
+				MarkdownDescription: "Identifier of the instance group to scale. A group has at most one policy. Changing it forces a new policy to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Region of the instance group. Defaults to the provider region. Changing it forces a new policy to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project of the instance group. Defaults to the provider project. Changing it forces a new policy to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"min_size": schema.Int64Attribute{
+				MarkdownDescription: "Minimum number of instances of the group.",
+				Required:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 1000),
+				},
+			},
+			"max_size": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of instances of the group. At least `min_size`.",
+				Required:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 1000),
+				},
+			},
+			"cooldown_seconds": schema.Int64Attribute{
+				MarkdownDescription: "Minimum number of seconds between two scaling actions. Defaults to `300`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(300),
+				Validators: []validator.Int64{
+					int64validator.Between(60, 3600),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"target": schema.ListNestedBlock{
+				MarkdownDescription: "Metric to keep at a target value. The group is scaled out when any metric is above its target, " +
+					"and scaled in when all of them are below. At least one is required.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"metric": schema.StringAttribute{
+							MarkdownDescription: fmt.Sprintf("Metric averaged over the instances of the group, one of `%s`, `%s` or `%s`.",
+								autoscalingMetricCPU, autoscalingMetricMemory, autoscalingMetricRequests),
+							Required: true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(autoscalingMetricCPU, autoscalingMetricMemory, autoscalingMetricRequests),
+							},
+						},
+						"value": schema.Float64Attribute{
+							MarkdownDescription: "Target value of the metric, a percentage for utilization metrics.",
+							Required:            true,
+							Validators: []validator.Float64{
+								float64validator.AtLeast(0.01),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *AutoscalingPolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *AutoscalingPolicyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data AutoscalingPolicyResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.MinSize.IsUnknown() && !data.MaxSize.IsUnknown() && data.MinSize.ValueInt64() > data.MaxSize.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(path.Root("max_size"), "Invalid Size",
+			fmt.Sprintf("max_size (%d) must be at least min_size (%d).", data.MaxSize.ValueInt64(), data.MinSize.ValueInt64()))
+	}
+
+	metrics := map[string]bool{}
+
+	for i, target := range data.Targets {
+		if target.Metric.IsUnknown() || target.Value.IsUnknown() {
+			continue
+		}
+
+		metric := target.Metric.ValueString()
+
+		if metrics[metric] {
+			resp.Diagnostics.AddAttributeError(path.Root("target").AtListIndex(i).AtName("metric"), "Duplicate Target",
+				fmt.Sprintf("Metric %q is already targeted by another target block.", metric))
+		}
+
+		metrics[metric] = true
+
+		if metric != autoscalingMetricRequests && target.Value.ValueFloat64() > 100 {
+			resp.Diagnostics.AddAttributeError(path.Root("target").AtListIndex(i).AtName("value"), "Invalid Target Value",
+				fmt.Sprintf("The target of %s is a percentage, got %g.", metric, target.Value.ValueFloat64()))
+		}
+	}
+}
+
+func (r *AutoscalingPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.planScope(ctx, req.Config, req.State, req.Plan, resp)
+}
+
+func (r *AutoscalingPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data AutoscalingPolicyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	policy, err := client.CreateAutoscalingPolicy(ctx, expandAutoscalingPolicy(data))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to create autoscaling policy", err.Error())
+		return
+	}
+
+	tflog.Trace(ctx, "created an autoscaling policy")
+
+	setAutoscalingPolicy(&data, client, policy)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AutoscalingPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AutoscalingPolicyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	policy, err := client.GetAutoscalingPolicy(ctx, int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read autoscaling policy, got error: %s", err), err.Error())
+		return
+	}
+
+	setAutoscalingPolicy(&data, client, policy)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AutoscalingPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data AutoscalingPolicyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	policy, err := client.UpdateAutoscalingPolicy(ctx, expandAutoscalingPolicy(data))
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to update autoscaling policy, got error: %s", err), err.Error())
+		return
+	}
+
+	setAutoscalingPolicy(&data, client, policy)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AutoscalingPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data AutoscalingPolicyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	// The instance group keeps the size it was last scaled to.
+	err := client.DeleteAutoscalingPolicy(ctx, int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to delete autoscaling policy, got error: %s", err), err.Error())
+		return
+	}
+}
+
+func (r *AutoscalingPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importRegional(ctx, req, resp)
+}
+
+// expandAutoscalingPolicy converts the model into the policy sent to the API.
+func expandAutoscalingPolicy(data AutoscalingPolicyResourceModel) *fakecloud.AutoscalingPolicy {
+	policy := &fakecloud.AutoscalingPolicy{
+		ID:              int(data.ID.ValueInt64()),
+		InstanceGroupID: int(data.InstanceGroupID.ValueInt64()),
+		MinSize:         int(data.MinSize.ValueInt64()),
+		MaxSize:         int(data.MaxSize.ValueInt64()),
+		CooldownSeconds: int(data.CooldownSeconds.ValueInt64()),
+	}
+
+	for _, target := range data.Targets {
+		policy.Targets = append(policy.Targets, fakecloud.AutoscalingTarget{
+			Metric: target.Metric.ValueString(),
+			Value:  target.Value.ValueFloat64(),
+		})
+	}
+
+	return policy
+}
+
+// setAutoscalingPolicy copies the policy returned by the API into the model.
+func setAutoscalingPolicy(data *AutoscalingPolicyResourceModel, client *apiClient, policy *fakecloud.AutoscalingPolicy) {
+	data.ID = types.Int64Value(int64(policy.ID))
+	data.InstanceGroupID = types.Int64Value(int64(policy.InstanceGroupID))
+	data.Region = regionValue(client.region)
+	data.Project = projectValue(client.project)
+	data.MinSize = types.Int64Value(int64(policy.MinSize))
+	data.MaxSize = types.Int64Value(int64(policy.MaxSize))
+	data.CooldownSeconds = types.Int64Value(int64(policy.CooldownSeconds))
+
+	data.Targets = make([]AutoscalingTargetModel, 0, len(policy.Targets))
+
+	for _, target := range policy.Targets {
+		data.Targets = append(data.Targets, AutoscalingTargetModel{
+			Metric: types.StringValue(target.Metric),
+			Value:  types.Float64Value(target.Value),
+		})
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestAutoscalingPolicyRoundTrip(t *testing.T) {
+	data := AutoscalingPolicyResourceModel{
+		ID:              types.Int64Value(7),
+		InstanceGroupID: types.Int64Value(3),
+		Region:          types.StringValue("eu-west-1"),
+		MinSize:         types.Int64Value(2),
+		MaxSize:         types.Int64Value(10),
+		CooldownSeconds: types.Int64Value(300),
+		Targets: []AutoscalingTargetModel{
+			{Metric: types.StringValue(autoscalingMetricCPU), Value: types.Float64Value(60)},
+			{Metric: types.StringValue(autoscalingMetricRequests), Value: types.Float64Value(250.5)},
+		},
+	}
+
+	var got AutoscalingPolicyResourceModel
+	setAutoscalingPolicy(&got, &apiClient{region: "eu-west-1"}, expandAutoscalingPolicy(data))
+
+	if diff := cmp.Diff(data, got); diff != "" {
+		t.Errorf("unexpected policy (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &BackupPolicyAttachmentResource{}
+var _ resource.ResourceWithImportState = &BackupPolicyAttachmentResource{}
+var _ resource.ResourceWithModifyPlan = &BackupPolicyAttachmentResource{}
+
+func NewBackupPolicyAttachmentResource() resource.Resource {
+	return &BackupPolicyAttachmentResource{}
+}
+
+// BackupPolicyAttachmentResource defines the resource implementation.
+type BackupPolicyAttachmentResource struct {
+	providerData *providerData
+}
+
+// BackupPolicyAttachmentResourceModel describes the resource data model.
+type BackupPolicyAttachmentResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	PolicyID types.Int64  `tfsdk:"policy_id"`
+	VMID     types.Int64  `tfsdk:"vm_id"`
+	Region   types.String `tfsdk:"region"`
+	Project  types.String `tfsdk:"project"`
+}
+
+func (r *BackupPolicyAttachmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_backup_policy_attachment"
+}
+
+func (r *BackupPolicyAttachmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Applies a `fakecloud_backup_policy` to a VM, which is then backed up on the schedule of the policy. A VM can have several backup policies. " +
+			"Import with `<policy_id>/<vm_id>`, prefixed with `<region>/` outside the provider region, or `<project>/<region>/` outside the provider project.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Attachment identifier, `<policy_id>/<vm_id>`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"policy_id": schema.Int64Attribute{
+				MarkdownDescription: "Identifier of the backup policy. Changing it forces a new attachment to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"vm_id": schema.Int64Attribute{
+				MarkdownDescription: "Identifier of the VM. Changing it forces a new attachment to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Region of the backup policy and VM. Defaults to the provider region. Changing it forces a new attachment to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project of the backup policy and VM. Defaults to the provider project. Changing it forces a new attachment to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *BackupPolicyAttachmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *BackupPolicyAttachmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.planScope(ctx, req.Config, req.State, req.Plan, resp)
+}
+
+func (r *BackupPolicyAttachmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data BackupPolicyAttachmentResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	policyID, vmID := int(data.PolicyID.ValueInt64()), int(data.VMID.ValueInt64())
+
+	err := client.AttachBackupPolicy(ctx, policyID, vmID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to attach backup policy", err.Error())
+		return
+	}
+
+	tflog.Trace(ctx, "attached a backup policy")
+
+	data.ID = types.StringValue(fmt.Sprintf("%d/%d", policyID, vmID))
+	data.Region = regionValue(client.region)
+	data.Project = projectValue(client.project)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BackupPolicyAttachmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data BackupPolicyAttachmentResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	policyID, vmID := int(data.PolicyID.ValueInt64()), int(data.VMID.ValueInt64())
+
+	_, err := client.GetBackupPolicyAttachment(ctx, policyID, vmID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read backup policy attachment, got error: %s", err), err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%d/%d", policyID, vmID))
+	data.Region = regionValue(client.region)
+	data.Project = projectValue(client.project)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BackupPolicyAttachmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	updateReplaceOnly(ctx, req, resp)
+}
+
+func (r *BackupPolicyAttachmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data BackupPolicyAttachmentResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	err := client.DetachBackupPolicy(ctx, int(data.PolicyID.ValueInt64()), int(data.VMID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to detach backup policy, got error: %s", err), err.Error())
+		return
+	}
+}
+
+func (r *BackupPolicyAttachmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importRegionalChild(ctx, req, resp, "policy_id", "vm_id")
+}
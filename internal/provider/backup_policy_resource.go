@@ -0,0 +1,337 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &BackupPolicyResource{}
+var _ resource.ResourceWithImportState = &BackupPolicyResource{}
+var _ resource.ResourceWithModifyPlan = &BackupPolicyResource{}
+
+func NewBackupPolicyResource() resource.Resource {
+	return &BackupPolicyResource{}
+}
+
+// BackupPolicyResource defines the resource implementation.
+type BackupPolicyResource struct {
+	providerData *providerData
+}
+
+// BackupPolicyResourceModel describes the resource data model.
+type BackupPolicyResourceModel struct {
+	ID             types.Int64  `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Schedule       types.String `tfsdk:"schedule"`
+	RetentionCount types.Int64  `tfsdk:"retention_count"`
+	RetentionDays  types.Int64  `tfsdk:"retention_days"`
+	NextRunAt      types.String `tfsdk:"next_run_at"`
+	Region         types.String `tfsdk:"region"`
+	Project        types.String `tfsdk:"project"`
+}
+
+func (r *BackupPolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_backup_policy"
+}
+
+func (r *BackupPolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Backup policy taking scheduled backups of the VMs it is attached to with `fakecloud_backup_policy_attachment`. " +
+			"Import with `<id>`, prefixed with `<region>/` outside the provider region, or `<project>/<region>/` outside the provider project.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Backup policy identifier",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the backup policy",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 255),
+				},
+			},
+			"schedule": schema.StringAttribute{
+				MarkdownDescription: "Cron expression of the backup schedule, in UTC, e.g. `0 3 * * *` for every day at 03:00. " +
+					"Accepts the five standard fields `<minute> <hour> <day of month> <month> <day of week>` " +
+					"or one of `@hourly`, `@daily`, `@weekly`, `@monthly` and `@yearly`.",
+				Required: true,
+				Validators: []validator.String{
+					cronValidator{},
+				},
+			},
+			"retention_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of backups of each VM to keep, older ones are deleted. " +
+					"At least one of `retention_count` and `retention_days` must be set, a backup is deleted once it exceeds either.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 1000),
+					int64validator.AtLeastOneOf(path.MatchRoot("retention_days")),
+				},
+			},
+			"retention_days": schema.Int64Attribute{
+				MarkdownDescription: "Number of days to keep backups for.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 3650),
+				},
+			},
+			"next_run_at": schema.StringAttribute{
+				MarkdownDescription: "Time of the next scheduled backup, in RFC 3339 format, computed from `schedule`.",
+				Computed:            true,
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Region the backup policy is created in. Defaults to the provider region. Changing it forces a new backup policy to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project the backup policy belongs to. Defaults to the provider project. Changing it forces a new backup policy to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *BackupPolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *BackupPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.planScope(ctx, req.Config, req.State, req.Plan, resp)
+
+	// Nothing more to plan when the backup policy is destroyed or the provider
+	// is not configured yet.
+	if resp.Diagnostics.HasError() || req.Plan.Raw.IsNull() || r.providerData == nil {
+		return
+	}
+
+	var plan, state BackupPolicyResourceModel
+
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The next run is computed locally so that the plan shows it. It only
+	// changes with the schedule, the refresh moves it forward otherwise.
+	switch {
+	case plan.Schedule.IsUnknown():
+		plan.NextRunAt = types.StringUnknown()
+	case !req.State.Raw.IsNull() && plan.Schedule.Equal(state.Schedule) && !state.NextRunAt.IsNull():
+		plan.NextRunAt = state.NextRunAt
+	default:
+		plan.NextRunAt = nextRunAt(plan.Schedule.ValueString(), time.Now())
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *BackupPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data BackupPolicyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	policy, err := client.CreateBackupPolicy(ctx, data.expand())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to create backup policy", err.Error())
+		return
+	}
+
+	setBackupPolicy(&data, client, policy)
+
+	tflog.Trace(ctx, "created a backup policy")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BackupPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data BackupPolicyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	policy, err := client.GetBackupPolicy(ctx, int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read backup policy, got error: %s", err), err.Error())
+		return
+	}
+
+	setBackupPolicy(&data, client, policy)
+	data.NextRunAt = nextRunAt(policy.Schedule, time.Now())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BackupPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data BackupPolicyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	policy := data.expand()
+	policy.ID = int(data.ID.ValueInt64())
+
+	err := client.UpdateBackupPolicy(ctx, policy)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to update backup policy, got error: %s", err), err.Error())
+		return
+	}
+
+	if data.NextRunAt.IsUnknown() {
+		data.NextRunAt = nextRunAt(data.Schedule.ValueString(), time.Now())
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BackupPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data BackupPolicyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	err := client.DeleteBackupPolicy(ctx, int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to delete backup policy, got error: %s", err), err.Error())
+		return
+	}
+}
+
+func (r *BackupPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importRegional(ctx, req, resp)
+}
+
+// expand converts the model into the API representation of the policy.
+func (data BackupPolicyResourceModel) expand() *fakecloud.BackupPolicy {
+	return &fakecloud.BackupPolicy{
+		Name:           data.Name.ValueString(),
+		Schedule:       data.Schedule.ValueString(),
+		RetentionCount: int(data.RetentionCount.ValueInt64()),
+		RetentionDays:  int(data.RetentionDays.ValueInt64()),
+	}
+}
+
+// setBackupPolicy copies the backup policy returned by the API into the
+// model. A retention of 0 is unset. The API does not return the next run, it
+// is kept as planned when known.
+func setBackupPolicy(data *BackupPolicyResourceModel, client *apiClient, policy *fakecloud.BackupPolicy) {
+	data.ID = types.Int64Value(int64(policy.ID))
+	data.Name = types.StringValue(policy.Name)
+	data.Schedule = types.StringValue(policy.Schedule)
+	data.RetentionCount = types.Int64Null()
+	data.RetentionDays = types.Int64Null()
+	data.Region = regionValue(client.region)
+	data.Project = projectValue(client.project)
+
+	if policy.RetentionCount != 0 {
+		data.RetentionCount = types.Int64Value(int64(policy.RetentionCount))
+	}
+
+	if policy.RetentionDays != 0 {
+		data.RetentionDays = types.Int64Value(int64(policy.RetentionDays))
+	}
+
+	if data.NextRunAt.IsUnknown() {
+		data.NextRunAt = nextRunAt(policy.Schedule, time.Now())
+	}
+}
+
+// nextRunAt returns the next run of a cron schedule after now, null when the
+// schedule is invalid.
+func nextRunAt(schedule string, now time.Time) types.String {
+	parsed, err := parseCron(schedule)
+	if err != nil {
+		return types.StringNull()
+	}
+
+	next, ok := parsed.next(now)
+	if !ok {
+		return types.StringNull()
+	}
+
+	return types.StringValue(next.Format(time.RFC3339))
+}
@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &backupsDataSource{}
+	_ datasource.DataSourceWithConfigure = &backupsDataSource{}
+)
+
+func NewBackupsDataSource() datasource.DataSource {
+	return &backupsDataSource{}
+}
+
+type backupsDataSource struct {
+	providerData *providerData
+}
+
+// backupsDataSourceModel maps the data source schema data.
+type backupsDataSourceModel struct {
+	VMID     types.Int64   `tfsdk:"vm_id"`
+	PolicyID types.Int64   `tfsdk:"policy_id"`
+	Region   types.String  `tfsdk:"region"`
+	Project  types.String  `tfsdk:"project"`
+	Backups  []backupModel `tfsdk:"backups"`
+}
+
+// backupModel maps backups schema data.
+type backupModel struct {
+	ID        types.Int64  `tfsdk:"id"`
+	VMID      types.Int64  `tfsdk:"vm_id"`
+	PolicyID  types.Int64  `tfsdk:"policy_id"`
+	Status    types.String `tfsdk:"status"`
+	SizeGB    types.Int64  `tfsdk:"size_gb"`
+	CreatedAt types.String `tfsdk:"created_at"`
+	ExpiresAt types.String `tfsdk:"expires_at"`
+}
+
+func (d *backupsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_backups"
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *backupsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.providerData = providerData
+}
+
+// Schema defines the schema for the data source.
+func (d *backupsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the backups, i.e. restore points, taken by `fakecloud_backup_policy`, " +
+			"optionally only those of a VM or of a backup policy.",
+
+		Attributes: map[string]schema.Attribute{
+			"vm_id": schema.Int64Attribute{
+				MarkdownDescription: "Identifier of the VM to list the backups of. Defaults to every VM.",
+				Optional:            true,
+			},
+			"policy_id": schema.Int64Attribute{
+				MarkdownDescription: "Identifier of the backup policy to list the backups of. Defaults to every backup policy.",
+				Optional:            true,
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Region to list the backups of. Defaults to the provider region.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project to list the backups of. Defaults to the provider project.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"backups": schema.ListNestedAttribute{
+				MarkdownDescription: "Backups matching the filters.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							MarkdownDescription: "Backup identifier.",
+							Computed:            true,
+						},
+						"vm_id": schema.Int64Attribute{
+							MarkdownDescription: "Identifier of the backed up VM.",
+							Computed:            true,
+						},
+						"policy_id": schema.Int64Attribute{
+							MarkdownDescription: "Identifier of the backup policy that took the backup.",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "Status of the backup, only `available` backups can be restored.",
+							Computed:            true,
+						},
+						"size_gb": schema.Int64Attribute{
+							MarkdownDescription: "Size of the backup, in GB.",
+							Computed:            true,
+						},
+						"created_at": schema.StringAttribute{
+							MarkdownDescription: "Creation time of the backup, in RFC 3339 format.",
+							Computed:            true,
+						},
+						"expires_at": schema.StringAttribute{
+							MarkdownDescription: "Time the backup is deleted at under the retention of its policy, in RFC 3339 format, null when it is only deleted by count.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *backupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state backupsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.providerData.client(state.Project.ValueString(), state.Region.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("region"), "Unable to Create Fakecloud API Client", err.Error())
+		return
+	}
+
+	// Null filters are read as 0, any VM or policy.
+	backups, err := client.GetBackups(ctx, int(state.VMID.ValueInt64()), int(state.PolicyID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Fakecloud Backups",
+			err.Error(),
+		)
+		return
+	}
+
+	state.Region = regionValue(client.region)
+	state.Project = projectValue(client.project)
+
+	// Map response body to model, an empty list rather than null when there
+	// are no backups yet.
+	state.Backups = make([]backupModel, 0, len(backups))
+
+	for _, backup := range backups {
+		backupState := backupModel{
+			ID:        types.Int64Value(int64(backup.ID)),
+			VMID:      types.Int64Value(int64(backup.VMID)),
+			PolicyID:  types.Int64Value(int64(backup.PolicyID)),
+			Status:    types.StringValue(backup.Status),
+			SizeGB:    types.Int64Value(int64(backup.SizeGB)),
+			CreatedAt: types.StringValue(backup.CreatedAt.Format(time.RFC3339)),
+			ExpiresAt: types.StringNull(),
+		}
+
+		if !backup.ExpiresAt.IsZero() {
+			backupState.ExpiresAt = types.StringValue(backup.ExpiresAt.Format(time.RFC3339))
+		}
+
+		state.Backups = append(state.Backups, backupState)
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
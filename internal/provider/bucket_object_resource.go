@@ -0,0 +1,406 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"os"
+	pathpkg "path"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &BucketObjectResource{}
+var _ resource.ResourceWithImportState = &BucketObjectResource{}
+var _ resource.ResourceWithModifyPlan = &BucketObjectResource{}
+
+// defaultContentType is the content type of objects whose key extension is
+// not recognized.
+const defaultContentType = "application/octet-stream"
+
+func NewBucketObjectResource() resource.Resource {
+	return &BucketObjectResource{}
+}
+
+// BucketObjectResource defines the resource implementation.
+type BucketObjectResource struct {
+	providerData *providerData
+}
+
+// BucketObjectResourceModel describes the resource data model.
+type BucketObjectResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Bucket      types.String `tfsdk:"bucket"`
+	Key         types.String `tfsdk:"key"`
+	Region      types.String `tfsdk:"region"`
+	Project     types.String `tfsdk:"project"`
+	Content     types.String `tfsdk:"content"`
+	Source      types.String `tfsdk:"source"`
+	ContentType types.String `tfsdk:"content_type"`
+	ETag        types.String `tfsdk:"etag"`
+	Size        types.Int64  `tfsdk:"size"`
+	VersionID   types.String `tfsdk:"version_id"`
+}
+
+func (r *BucketObjectResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bucket_object"
+}
+
+func (r *BucketObjectResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Object stored in a `fakecloud_bucket`. The MD5 digest of `content` or of the `source` file is " +
+			"computed at plan time, so changes to the file show up in the plan as a change of `etag`. " +
+			"Import with `<bucket>/<key>`, prefixed with `<region>:` outside the provider region, or `<project>:<region>:` outside the provider project.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Object identifier, `<bucket>/<key>`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"bucket": schema.StringAttribute{
+				MarkdownDescription: "Name of the bucket. Changing it forces a new object to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key": schema.StringAttribute{
+				MarkdownDescription: "Key of the object in the bucket, e.g. `bootstrap/init.sh`. Changing it forces a new object to be created.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 1024),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Region of the bucket. Defaults to the provider region. Changing it forces a new object to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project of the bucket. Defaults to the provider project. Changing it forces a new object to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"content": schema.StringAttribute{
+				MarkdownDescription: "Content of the object, as UTF-8 text. Exactly one of `content` and `source` must be set.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("source")),
+				},
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "Path of a local file to upload as the content of the object.",
+				Optional:            true,
+			},
+			"content_type": schema.StringAttribute{
+				MarkdownDescription: "MIME type of the object. Detected from the extension of `key` when omitted, " +
+					"`application/octet-stream` when the extension is not recognized.",
+				Optional: true,
+				Computed: true,
+			},
+			"etag": schema.StringAttribute{
+				MarkdownDescription: "Hex encoded MD5 digest of the content of the object.",
+				Computed:            true,
+			},
+			"size": schema.Int64Attribute{
+				MarkdownDescription: "Size of the object in bytes.",
+				Computed:            true,
+			},
+			"version_id": schema.StringAttribute{
+				MarkdownDescription: "Version of the object, set when the bucket has versioning enabled.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *BucketObjectResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *BucketObjectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.planScope(ctx, req.Config, req.State, req.Plan, resp)
+
+	// Nothing more to plan when the object is destroyed or the provider is not
+	// configured yet.
+	if resp.Diagnostics.HasError() || req.Plan.Raw.IsNull() || r.providerData == nil {
+		return
+	}
+
+	var config, plan, state BucketObjectResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.ContentType.IsNull() {
+		plan.ContentType = types.StringUnknown()
+
+		if !plan.Key.IsUnknown() {
+			plan.ContentType = types.StringValue(detectContentType(plan.Key.ValueString()))
+		}
+	}
+
+	// Digest the content now rather than at apply time, so that a changed
+	// source file shows up in the plan.
+	plan.ETag, plan.Size = types.StringUnknown(), types.Int64Unknown()
+
+	switch {
+	case plan.Content.IsUnknown() || plan.Source.IsUnknown():
+	case !plan.Content.IsNull():
+		body := []byte(plan.Content.ValueString())
+		plan.ETag, plan.Size = types.StringValue(bucketObjectETag(body)), types.Int64Value(int64(len(body)))
+	case !plan.Source.IsNull():
+		body, err := os.ReadFile(plan.Source.ValueString())
+
+		// The file may be generated during the apply, it is digested once
+		// uploaded.
+		if errors.Is(err, fs.ErrNotExist) {
+			break
+		}
+
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("source"), "Unable to Read Source File", err.Error())
+			return
+		}
+
+		plan.ETag, plan.Size = types.StringValue(bucketObjectETag(body)), types.Int64Value(int64(len(body)))
+	}
+
+	// A new version is only written when the object is uploaded again.
+	plan.VersionID = types.StringUnknown()
+
+	if !req.State.Raw.IsNull() && plan.ETag.Equal(state.ETag) && plan.ContentType.Equal(state.ContentType) {
+		plan.VersionID = state.VersionID
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *BucketObjectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data BucketObjectResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	r.put(ctx, client, &data, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created a bucket object")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketObjectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data BucketObjectResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	object, err := client.GetBucketObject(ctx, data.Bucket.ValueString(), data.Key.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read bucket object, got error: %s", err), err.Error())
+		return
+	}
+
+	setBucketObject(&data, client, object)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketObjectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data BucketObjectResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	// Objects are immutable: the content, its source and its type can only
+	// change by uploading the object again.
+	r.put(ctx, client, &data, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketObjectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data BucketObjectResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	err := client.DeleteBucketObject(ctx, data.Bucket.ValueString(), data.Key.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to delete bucket object, got error: %s", err), err.Error())
+		return
+	}
+}
+
+func (r *BucketObjectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	project, region, bucket, key, err := parseBucketObjectImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("bucket"), bucket)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("key"), key)...)
+	setImportScope(ctx, resp, project, region)
+}
+
+// put uploads the content of the object and updates the model with the
+// stored object.
+func (r *BucketObjectResource) put(ctx context.Context, client *apiClient, data *BucketObjectResourceModel, diags *diag.Diagnostics) {
+	body := []byte(data.Content.ValueString())
+
+	if !data.Source.IsNull() {
+		var err error
+
+		body, err = os.ReadFile(data.Source.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("source"), "Unable to Read Source File", err.Error())
+			return
+		}
+	}
+
+	if !data.ETag.IsUnknown() && data.ETag.ValueString() != bucketObjectETag(body) {
+		diags.AddAttributeError(path.Root("source"), "Source File Changed",
+			"The source file changed between plan and apply. Run terraform apply again to upload the new content.")
+		return
+	}
+
+	object, err := client.PutBucketObject(ctx, data.Bucket.ValueString(), data.Key.ValueString(), body, data.ContentType.ValueString())
+	if err != nil {
+		diags.AddError("Unable to upload bucket object", err.Error())
+		return
+	}
+
+	setBucketObject(data, client, object)
+}
+
+// setBucketObject copies the object returned by the API into the model. The
+// content itself is not returned by the API and is kept as is.
+func setBucketObject(data *BucketObjectResourceModel, client *apiClient, object *fakecloud.BucketObject) {
+	data.ID = types.StringValue(object.Bucket + "/" + object.Key)
+	data.Bucket = types.StringValue(object.Bucket)
+	data.Key = types.StringValue(object.Key)
+	data.Region = regionValue(client.region)
+	data.Project = projectValue(client.project)
+	data.ContentType = types.StringValue(object.ContentType)
+	data.ETag = types.StringValue(object.ETag)
+	data.Size = types.Int64Value(object.Size)
+	data.VersionID = types.StringNull()
+
+	if object.VersionID != "" {
+		data.VersionID = types.StringValue(object.VersionID)
+	}
+}
+
+// bucketObjectETag returns the etag of an object with the given content: the
+// hex encoded MD5 digest of the content.
+func bucketObjectETag(body []byte) string {
+	sum := md5.Sum(body)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// detectContentType returns the MIME type of an object from the extension of
+// its key.
+func detectContentType(key string) string {
+	if contentType := mime.TypeByExtension(pathpkg.Ext(key)); contentType != "" {
+		return contentType
+	}
+
+	return defaultContentType
+}
@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import "testing"
+
+func TestBucketObjectETag(t *testing.T) {
+	cases := map[string]string{
+		"":            "d41d8cd98f00b204e9800998ecf8427e",
+		"hello world": "5eb63bbbe01eeed093cb22bb8f5acdc3",
+	}
+
+	for body, expected := range cases {
+		if actual := bucketObjectETag([]byte(body)); actual != expected {
+			t.Errorf("%q: expected %q, got %q", body, expected, actual)
+		}
+	}
+}
+
+func TestDetectContentType(t *testing.T) {
+	cases := map[string]string{
+		"config.json":                          "application/json",
+		"site/index.html":                      "text/html; charset=utf-8",
+		"images/logo.PNG":                      "image/png",
+		"bootstrap/artifact":                   defaultContentType,
+		"bootstrap/artifact.unknown-extension": defaultContentType,
+	}
+
+	for key, expected := range cases {
+		t.Run(key, func(t *testing.T) {
+			if actual := detectContentType(key); actual != expected {
+				t.Errorf("expected %q, got %q", expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,394 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &BucketResource{}
+var _ resource.ResourceWithImportState = &BucketResource{}
+var _ resource.ResourceWithModifyPlan = &BucketResource{}
+var _ resource.ResourceWithValidateConfig = &BucketResource{}
+
+// bucketNameRegexp matches valid bucket names: 3 to 63 lower case letters,
+// digits, dots and hyphens, starting and ending with a letter or digit.
+var bucketNameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+
+// ACLs a bucket can be created with.
+var bucketACLs = []string{"private", "public-read"}
+
+func NewBucketResource() resource.Resource {
+	return &BucketResource{}
+}
+
+// BucketResource defines the resource implementation.
+type BucketResource struct {
+	providerData *providerData
+}
+
+// BucketResourceModel describes the resource data model.
+type BucketResourceModel struct {
+	ID             types.String               `tfsdk:"id"`
+	Name           types.String               `tfsdk:"name"`
+	Region         types.String               `tfsdk:"region"`
+	Project        types.String               `tfsdk:"project"`
+	Versioning     types.Bool                 `tfsdk:"versioning"`
+	ACL            types.String               `tfsdk:"acl"`
+	LifecycleRules []BucketLifecycleRuleModel `tfsdk:"lifecycle_rule"`
+}
+
+// BucketLifecycleRuleModel describes a lifecycle_rule block.
+type BucketLifecycleRuleModel struct {
+	ID                              types.String `tfsdk:"id"`
+	Enabled                         types.Bool   `tfsdk:"enabled"`
+	Prefix                          types.String `tfsdk:"prefix"`
+	ExpirationDays                  types.Int64  `tfsdk:"expiration_days"`
+	NoncurrentVersionExpirationDays types.Int64  `tfsdk:"noncurrent_version_expiration_days"`
+}
+
+func (r *BucketResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bucket"
+}
+
+func (r *BucketResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Object storage bucket. Import with `<name>`, prefixed with `<region>/` outside the provider region, or `<project>/<region>/` outside the provider project.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Bucket identifier, the same as `name`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the bucket: 3 to 63 lower case letters, digits, dots and hyphens. Changing it forces a new bucket to be created.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(bucketNameRegexp, "must be 3 to 63 lower case letters, digits, dots and hyphens, starting and ending with a letter or digit"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Region to create the bucket in. Defaults to the provider region. Changing it forces a new bucket to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project the bucket belongs to. Defaults to the provider project. Changing it forces a new bucket to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"versioning": schema.BoolAttribute{
+				MarkdownDescription: "Whether to keep the previous versions of overwritten and deleted objects. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"acl": schema.StringAttribute{
+				MarkdownDescription: "Canned ACL of the bucket, one of `private` or `public-read`. Defaults to `private`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("private"),
+				Validators: []validator.String{
+					stringvalidator.OneOf(bucketACLs...),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"lifecycle_rule": schema.ListNestedBlock{
+				MarkdownDescription: "Rule expiring the objects of the bucket, or their previous versions.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Unique identifier of the rule.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.LengthBetween(1, 255),
+							},
+						},
+						"enabled": schema.BoolAttribute{
+							MarkdownDescription: "Whether the rule is applied. Defaults to `true`.",
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(true),
+						},
+						"prefix": schema.StringAttribute{
+							MarkdownDescription: "Key prefix of the objects the rule applies to. The rule applies to every object when omitted.",
+							Optional:            true,
+						},
+						"expiration_days": schema.Int64Attribute{
+							MarkdownDescription: "Number of days after their creation objects are deleted.",
+							Optional:            true,
+							Validators: []validator.Int64{
+								int64validator.AtLeast(1),
+							},
+						},
+						"noncurrent_version_expiration_days": schema.Int64Attribute{
+							MarkdownDescription: "Number of days after they were overwritten or deleted previous versions of objects are deleted. Requires `versioning`.",
+							Optional:            true,
+							Validators: []validator.Int64{
+								int64validator.AtLeast(1),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *BucketResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *BucketResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data BucketResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ids := make(map[string]bool, len(data.LifecycleRules))
+
+	for i, rule := range data.LifecycleRules {
+		rulePath := path.Root("lifecycle_rule").AtListIndex(i)
+
+		if !rule.ID.IsUnknown() && !rule.ID.IsNull() {
+			if ids[rule.ID.ValueString()] {
+				resp.Diagnostics.AddAttributeError(rulePath.AtName("id"), "Duplicate Lifecycle Rule",
+					fmt.Sprintf("Another lifecycle rule has the identifier %q.", rule.ID.ValueString()))
+			}
+
+			ids[rule.ID.ValueString()] = true
+		}
+
+		if rule.ExpirationDays.IsNull() && rule.NoncurrentVersionExpirationDays.IsNull() {
+			resp.Diagnostics.AddAttributeError(rulePath, "Invalid Lifecycle Rule",
+				"A lifecycle rule must set expiration_days, noncurrent_version_expiration_days or both.")
+		}
+
+		if !rule.NoncurrentVersionExpirationDays.IsNull() && !data.Versioning.IsUnknown() && !data.Versioning.ValueBool() {
+			resp.Diagnostics.AddAttributeError(rulePath.AtName("noncurrent_version_expiration_days"), "Invalid Lifecycle Rule",
+				"noncurrent_version_expiration_days only applies to buckets with versioning enabled.")
+		}
+	}
+}
+
+func (r *BucketResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.planScope(ctx, req.Config, req.State, req.Plan, resp)
+}
+
+func (r *BucketResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data BucketResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	bucket, err := client.CreateBucket(ctx, expandBucket(data))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to create bucket", err.Error())
+		return
+	}
+
+	setBucket(&data, client, bucket)
+
+	tflog.Trace(ctx, "created a bucket")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data BucketResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	bucket, err := client.GetBucket(ctx, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read bucket, got error: %s", err), err.Error())
+		return
+	}
+
+	setBucket(&data, client, bucket)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data BucketResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	bucket, err := client.UpdateBucket(ctx, expandBucket(data))
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to update bucket, got error: %s", err), err.Error())
+		return
+	}
+
+	setBucket(&data, client, bucket)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data BucketResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	err := client.DeleteBucket(ctx, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to delete bucket, got error: %s", err), err.Error())
+		return
+	}
+}
+
+func (r *BucketResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	project, region, name, err := parseBucketImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+	setImportScope(ctx, resp, project, region)
+}
+
+// expandBucket converts the model into the bucket sent to the API.
+func expandBucket(data BucketResourceModel) *fakecloud.Bucket {
+	bucket := &fakecloud.Bucket{
+		Name:           data.Name.ValueString(),
+		Versioning:     data.Versioning.ValueBool(),
+		ACL:            data.ACL.ValueString(),
+		LifecycleRules: make([]fakecloud.BucketLifecycleRule, 0, len(data.LifecycleRules)),
+	}
+
+	for _, rule := range data.LifecycleRules {
+		bucket.LifecycleRules = append(bucket.LifecycleRules, fakecloud.BucketLifecycleRule{
+			ID:                              rule.ID.ValueString(),
+			Enabled:                         rule.Enabled.ValueBool(),
+			Prefix:                          rule.Prefix.ValueString(),
+			ExpirationDays:                  int(rule.ExpirationDays.ValueInt64()),
+			NoncurrentVersionExpirationDays: int(rule.NoncurrentVersionExpirationDays.ValueInt64()),
+		})
+	}
+
+	return bucket
+}
+
+// setBucket copies the bucket returned by the API into the model.
+func setBucket(data *BucketResourceModel, client *apiClient, bucket *fakecloud.Bucket) {
+	data.ID = types.StringValue(bucket.Name)
+	data.Name = types.StringValue(bucket.Name)
+	data.Region = regionValue(client.region)
+	data.Project = projectValue(client.project)
+	data.Versioning = types.BoolValue(bucket.Versioning)
+	data.ACL = types.StringValue(bucket.ACL)
+	data.LifecycleRules = nil
+
+	for _, rule := range bucket.LifecycleRules {
+		r := BucketLifecycleRuleModel{
+			ID:                              types.StringValue(rule.ID),
+			Enabled:                         types.BoolValue(rule.Enabled),
+			Prefix:                          types.StringNull(),
+			ExpirationDays:                  types.Int64Null(),
+			NoncurrentVersionExpirationDays: types.Int64Null(),
+		}
+
+		if rule.Prefix != "" {
+			r.Prefix = types.StringValue(rule.Prefix)
+		}
+
+		if rule.ExpirationDays != 0 {
+			r.ExpirationDays = types.Int64Value(int64(rule.ExpirationDays))
+		}
+
+		if rule.NoncurrentVersionExpirationDays != 0 {
+			r.NoncurrentVersionExpirationDays = types.Int64Value(int64(rule.NoncurrentVersionExpirationDays))
+		}
+
+		data.LifecycleRules = append(data.LifecycleRules, r)
+	}
+}
@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &callerIdentityDataSource{}
+	_ datasource.DataSourceWithConfigure = &callerIdentityDataSource{}
+)
+
+func NewCallerIdentityDataSource() datasource.DataSource {
+	return &callerIdentityDataSource{}
+}
+
+type callerIdentityDataSource struct {
+	providerData *providerData
+}
+
+// callerIdentityDataSourceModel maps the data source schema data.
+type callerIdentityDataSourceModel struct {
+	AccountID types.String `tfsdk:"account_id"`
+	UserName  types.String `tfsdk:"user_name"`
+	AuthMode  types.String `tfsdk:"auth_mode"`
+}
+
+func (d *callerIdentityDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_caller_identity"
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *callerIdentityDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.providerData = providerData
+}
+
+// Schema defines the schema for the data source.
+func (d *callerIdentityDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Returns the account and user the provider is authenticated as.",
+
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the Fakecloud account.",
+				Computed:            true,
+			},
+			"user_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the authenticated user.",
+				Computed:            true,
+			},
+			"auth_mode": schema.StringAttribute{
+				MarkdownDescription: "How the provider is authenticated, e.g. `password` or `anonymous`.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *callerIdentityDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	client, err := d.providerData.client("", "")
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Create Fakecloud API Client", err.Error())
+		return
+	}
+
+	state, err := readCallerIdentity(ctx, client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Fakecloud Caller Identity",
+			err.Error(),
+		)
+		return
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// callerIdentityClient reads the identity a client is authenticated as.
+type callerIdentityClient interface {
+	GetCallerIdentity(ctx context.Context) (*fakecloud.CallerIdentity, error)
+}
+
+// readCallerIdentity returns the identity client is authenticated as.
+func readCallerIdentity(ctx context.Context, client callerIdentityClient) (callerIdentityDataSourceModel, error) {
+	var state callerIdentityDataSourceModel
+
+	identity, err := client.GetCallerIdentity(ctx)
+	if err != nil {
+		return state, err
+	}
+
+	state.AccountID = types.StringValue(identity.AccountID)
+	state.UserName = types.StringValue(identity.UserName)
+	state.AuthMode = types.StringValue(identity.AuthMode)
+
+	return state, nil
+}
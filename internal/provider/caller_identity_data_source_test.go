@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+// stubCallerIdentityClient answers GetCallerIdentity with identity and err.
+type stubCallerIdentityClient struct {
+	identity *fakecloud.CallerIdentity
+	err      error
+}
+
+func (c stubCallerIdentityClient) GetCallerIdentity(ctx context.Context) (*fakecloud.CallerIdentity, error) {
+	return c.identity, c.err
+}
+
+func TestReadCallerIdentity(t *testing.T) {
+	cases := map[string]struct {
+		client   stubCallerIdentityClient
+		expected callerIdentityDataSourceModel
+		err      bool
+	}{
+		"password": {
+			client: stubCallerIdentityClient{identity: &fakecloud.CallerIdentity{AccountID: "123", UserName: "ci", AuthMode: "password"}},
+			expected: callerIdentityDataSourceModel{
+				AccountID: types.StringValue("123"),
+				UserName:  types.StringValue("ci"),
+				AuthMode:  types.StringValue("password"),
+			},
+		},
+		"anonymous": {
+			client: stubCallerIdentityClient{identity: &fakecloud.CallerIdentity{AccountID: "123", AuthMode: "anonymous"}},
+			expected: callerIdentityDataSourceModel{
+				AccountID: types.StringValue("123"),
+				UserName:  types.StringValue(""),
+				AuthMode:  types.StringValue("anonymous"),
+			},
+		},
+		"error": {
+			client: stubCallerIdentityClient{err: errors.New("unexpected status code: 401")},
+			err:    true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			actual, err := readCallerIdentity(context.Background(), tc.client)
+
+			if tc.err {
+				if err == nil {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("unexpected caller identity (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"net/netip"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &CIDRSubnetsForZonesFunction{}
+
+func NewCIDRSubnetsForZonesFunction() function.Function {
+	return &CIDRSubnetsForZonesFunction{}
+}
+
+// CIDRSubnetsForZonesFunction defines the cidr_subnets_for_zones function implementation.
+type CIDRSubnetsForZonesFunction struct{}
+
+func (f *CIDRSubnetsForZonesFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cidr_subnets_for_zones"
+}
+
+func (f *CIDRSubnetsForZonesFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Split a network into one subnet per zone",
+		MarkdownDescription: "Splits an IPv4 or IPv6 network in CIDR notation into equally sized subnets, one per zone, " +
+			"and returns a map from zone name to subnet. Subnets are allocated in the order the zones are given, " +
+			"using the smallest prefix extension that fits all of them.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "cidr",
+				MarkdownDescription: "Network to split, e.g. `10.0.0.0/16`.",
+			},
+			function.ListParameter{
+				Name:                "zones",
+				MarkdownDescription: "Zone names, e.g. the `names` of the `fakecloud_zones` data source.",
+				ElementType:         types.StringType,
+			},
+		},
+		Return: function.MapReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *CIDRSubnetsForZonesFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var cidr string
+	var zones []string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &cidr, &zones))
+	if resp.Error != nil {
+		return
+	}
+
+	network, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0, fmt.Sprintf("invalid CIDR %q: %s", cidr, err)))
+		return
+	}
+
+	subnets, err := cidrSubnetsForZones(network, zones)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(1, err.Error()))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, subnets))
+}
+
+// cidrSubnetsForZones allocates one subnet of network per zone, in order.
+func cidrSubnetsForZones(network netip.Prefix, zones []string) (map[string]string, error) {
+	if len(zones) == 0 {
+		return nil, fmt.Errorf("at least one zone is required")
+	}
+
+	network = network.Masked()
+
+	// Smallest number of additional prefix bits numbering all the zones.
+	newBits := bits.Len(uint(len(zones) - 1))
+	prefixLen := network.Bits() + newBits
+	addrLen := network.Addr().BitLen()
+
+	if prefixLen > addrLen {
+		return nil, fmt.Errorf("%s is too small to be split into %d subnets", network, len(zones))
+	}
+
+	base := new(big.Int).SetBytes(network.Addr().AsSlice())
+	subnets := make(map[string]string, len(zones))
+
+	for i, zone := range zones {
+		if _, ok := subnets[zone]; ok {
+			return nil, fmt.Errorf("zone %q is listed more than once", zone)
+		}
+
+		offset := new(big.Int).Lsh(big.NewInt(int64(i)), uint(addrLen-prefixLen))
+		raw := new(big.Int).Add(base, offset).FillBytes(make([]byte, addrLen/8))
+
+		addr, ok := netip.AddrFromSlice(raw)
+		if !ok {
+			return nil, fmt.Errorf("unable to compute subnet %d of %s", i, network)
+		}
+
+		subnets[zone] = netip.PrefixFrom(addr, prefixLen).String()
+	}
+
+	return subnets, nil
+}
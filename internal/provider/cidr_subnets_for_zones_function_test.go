@@ -0,0 +1,127 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func zoneList(zones ...string) types.List {
+	values := make([]attr.Value, 0, len(zones))
+
+	for _, zone := range zones {
+		values = append(values, types.StringValue(zone))
+	}
+
+	return types.ListValueMust(types.StringType, values)
+}
+
+func TestCIDRSubnetsForZonesFunctionRun(t *testing.T) {
+	cases := map[string]struct {
+		request  function.RunRequest
+		expected function.RunResponse
+	}{
+		"three zones": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.StringValue("10.0.0.0/16"),
+					zoneList("eu-west-a", "eu-west-b", "eu-west-c"),
+				}),
+			},
+			expected: function.RunResponse{
+				Result: function.NewResultData(types.MapValueMust(types.StringType, map[string]attr.Value{
+					"eu-west-a": types.StringValue("10.0.0.0/18"),
+					"eu-west-b": types.StringValue("10.0.64.0/18"),
+					"eu-west-c": types.StringValue("10.0.128.0/18"),
+				})),
+			},
+		},
+		"single zone keeps the network": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.StringValue("10.1.2.3/24"),
+					zoneList("eu-west-a"),
+				}),
+			},
+			expected: function.RunResponse{
+				Result: function.NewResultData(types.MapValueMust(types.StringType, map[string]attr.Value{
+					"eu-west-a": types.StringValue("10.1.2.0/24"),
+				})),
+			},
+		},
+		"ipv6": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.StringValue("fd00::/48"),
+					zoneList("a", "b"),
+				}),
+			},
+			expected: function.RunResponse{
+				Result: function.NewResultData(types.MapValueMust(types.StringType, map[string]attr.Value{
+					"a": types.StringValue("fd00::/49"),
+					"b": types.StringValue("fd00:0:0:8000::/49"),
+				})),
+			},
+		},
+		"invalid cidr": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.StringValue("10.0.0.0"),
+					zoneList("a"),
+				}),
+			},
+			expected: function.RunResponse{
+				Error:  function.NewArgumentFuncError(0, `invalid CIDR "10.0.0.0": netip.ParsePrefix("10.0.0.0"): no '/'`),
+				Result: function.NewResultData(types.MapUnknown(types.StringType)),
+			},
+		},
+		"network too small": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.StringValue("10.0.0.1/32"),
+					zoneList("a", "b"),
+				}),
+			},
+			expected: function.RunResponse{
+				Error:  function.NewArgumentFuncError(1, "10.0.0.1/32 is too small to be split into 2 subnets"),
+				Result: function.NewResultData(types.MapUnknown(types.StringType)),
+			},
+		},
+		"duplicate zone": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.StringValue("10.0.0.0/16"),
+					zoneList("a", "a"),
+				}),
+			},
+			expected: function.RunResponse{
+				Error:  function.NewArgumentFuncError(1, `zone "a" is listed more than once`),
+				Result: function.NewResultData(types.MapUnknown(types.StringType)),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := function.RunResponse{
+				Result: function.NewResultData(types.MapUnknown(types.StringType)),
+			}
+
+			NewCIDRSubnetsForZonesFunction().Run(context.Background(), tc.request, &got)
+
+			if !got.Error.Equal(tc.expected.Error) {
+				t.Errorf("expected error %v, got %v", tc.expected.Error, got.Error)
+			}
+
+			if !got.Result.Equal(tc.expected.Result) {
+				t.Errorf("expected result %s, got %s", tc.expected.Result.Value(), got.Result.Value())
+			}
+		})
+	}
+}
@@ -0,0 +1,1083 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/time/rate"
+)
+
+// apiClient wraps the Fakecloud SDK client. Every resource and data source
+// talks to the API through it so that all calls are instrumented and
+// throttled the same way.
+type apiClient struct {
+	sdk     *fakecloud.Client
+	project string
+	region  string
+	tracer  trace.Tracer
+
+	// limiter and slots are shared by every call made through the client and
+	// are nil when the corresponding limit is disabled.
+	limiter *rate.Limiter
+	slots   chan struct{}
+}
+
+// apiLimits bounds the load a provider instance puts on the Fakecloud API.
+// A zero value disables the corresponding limit.
+type apiLimits struct {
+	RequestsPerSecond  int64
+	ConcurrentRequests int64
+}
+
+func newAPIClient(sdk *fakecloud.Client, tracer trace.Tracer, limits apiLimits) *apiClient {
+	c := &apiClient{
+		sdk:    sdk,
+		tracer: tracer,
+	}
+
+	if limits.RequestsPerSecond > 0 {
+		c.limiter = rate.NewLimiter(rate.Limit(limits.RequestsPerSecond), int(limits.RequestsPerSecond))
+	}
+
+	if limits.ConcurrentRequests > 0 {
+		c.slots = make(chan struct{}, limits.ConcurrentRequests)
+	}
+
+	return c
+}
+
+// forScope returns a client talking to project and region through sdk,
+// sharing the tracer and request limits of c.
+func (c *apiClient) forScope(project string, region string, sdk *fakecloud.Client) *apiClient {
+	scoped := *c
+	scoped.project = project
+	scoped.region = region
+	scoped.sdk = sdk
+
+	return &scoped
+}
+
+// acquire blocks until the call is allowed by both the request rate and the
+// concurrency limit, returning how long it had to wait. The returned release
+// function must be called once the call is done.
+func (c *apiClient) acquire(ctx context.Context) (time.Duration, func(), error) {
+	var wait time.Duration
+
+	if c.limiter != nil {
+		reservation := c.limiter.Reserve()
+
+		if delay := reservation.Delay(); delay > 0 {
+			timer := time.NewTimer(delay)
+			defer timer.Stop()
+
+			select {
+			case <-timer.C:
+				wait += delay
+			case <-ctx.Done():
+				reservation.Cancel()
+				return wait, nil, ctx.Err()
+			}
+		}
+	}
+
+	if c.slots == nil {
+		return wait, func() {}, nil
+	}
+
+	release := func() { <-c.slots }
+
+	select {
+	case c.slots <- struct{}{}:
+		return wait, release, nil
+	default:
+	}
+
+	start := time.Now()
+
+	select {
+	case c.slots <- struct{}{}:
+		return wait + time.Since(start), release, nil
+	case <-ctx.Done():
+		return wait + time.Since(start), nil, ctx.Err()
+	}
+}
+
+// statusCodePattern matches the status code the SDK reports in its errors
+// when the API answers with an unexpected status.
+var statusCodePattern = regexp.MustCompile(`status code: (\d{3})`)
+
+// httpStatusCode extracts the HTTP status code from an SDK error.
+func httpStatusCode(err error) (int, bool) {
+	for ; err != nil; err = errors.Unwrap(err) {
+		match := statusCodePattern.FindStringSubmatch(err.Error())
+		if match == nil {
+			continue
+		}
+
+		code, convErr := strconv.Atoi(match[1])
+		if convErr != nil {
+			return 0, false
+		}
+
+		return code, true
+	}
+
+	return 0, false
+}
+
+// invoke runs a single Fakecloud API call inside a client span named after op,
+// once the request limits of the client allow it.
+func invoke[T any](ctx context.Context, c *apiClient, op string, call func() (T, error), attrs ...attribute.KeyValue) (T, error) {
+	if c.region != "" {
+		attrs = append(attrs, attrRegion.String(c.region))
+	}
+
+	if c.project != "" {
+		attrs = append(attrs, attrProject.String(c.project))
+	}
+
+	ctx, span := c.tracer.Start(ctx, "fakecloud."+op,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	defer span.End()
+
+	wait, release, err := c.acquire(ctx)
+	if wait > 0 {
+		span.SetAttributes(attrThrottleWait.Int64(wait.Milliseconds()))
+		tflog.Debug(ctx, "Waited for Fakecloud API request limits", map[string]interface{}{
+			"operation": op,
+			"wait":      wait.String(),
+		})
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		var zero T
+		return zero, err
+	}
+	defer release()
+
+	result, err := call()
+	if err == nil {
+		// The SDK does not expose the response, a call it returns from without
+		// error got a success status and is recorded as 200 OK.
+		span.SetAttributes(attrHTTPStatusCode.Int(http.StatusOK))
+
+		return result, nil
+	}
+
+	if code, ok := httpStatusCode(err); ok {
+		span.SetAttributes(attrHTTPStatusCode.Int(code))
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+
+	return result, err
+}
+
+// invokeNoResult is invoke for API calls that only return an error.
+func invokeNoResult(ctx context.Context, c *apiClient, op string, call func() error, attrs ...attribute.KeyValue) error {
+	_, err := invoke(ctx, c, op, func() (struct{}, error) {
+		return struct{}{}, call()
+	}, attrs...)
+
+	return err
+}
+
+func (c *apiClient) CreateVM(ctx context.Context, vm *fakecloud.VirtualMachine) (*fakecloud.VirtualMachine, error) {
+	created, err := invoke(ctx, c, "CreateVM", func() (*fakecloud.VirtualMachine, error) {
+		return c.sdk.CreateVM(vm)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The identifier is only known once the call returns, record it on the
+	// RPC span instead.
+	trace.SpanFromContext(ctx).SetAttributes(attrVMID.Int(created.ID))
+
+	return created, nil
+}
+
+func (c *apiClient) GetVM(ctx context.Context, id int) (*fakecloud.VirtualMachine, error) {
+	return invoke(ctx, c, "GetVM", func() (*fakecloud.VirtualMachine, error) {
+		return c.sdk.GetVM(id)
+	}, attrVMID.Int(id))
+}
+
+func (c *apiClient) GetVMs(ctx context.Context) ([]fakecloud.VirtualMachine, error) {
+	return invoke(ctx, c, "GetVMs", func() ([]fakecloud.VirtualMachine, error) {
+		return c.sdk.GetVMs()
+	})
+}
+
+func (c *apiClient) UpdateVM(ctx context.Context, id int, name string, instanceType string) error {
+	return invokeNoResult(ctx, c, "UpdateVM", func() error {
+		return c.sdk.UpdateVM(id, name, instanceType)
+	}, attrVMID.Int(id))
+}
+
+func (c *apiClient) DeleteVM(ctx context.Context, id int) error {
+	return invokeNoResult(ctx, c, "DeleteVM", func() error {
+		return c.sdk.DeleteVM(id)
+	}, attrVMID.Int(id))
+}
+
+func (c *apiClient) GetRegions(ctx context.Context) ([]fakecloud.Region, error) {
+	return invoke(ctx, c, "GetRegions", func() ([]fakecloud.Region, error) {
+		return c.sdk.GetRegions()
+	})
+}
+
+func (c *apiClient) GetZones(ctx context.Context) ([]fakecloud.Zone, error) {
+	return invoke(ctx, c, "GetZones", func() ([]fakecloud.Zone, error) {
+		return c.sdk.GetZones()
+	})
+}
+
+func (c *apiClient) StopVM(ctx context.Context, id int) error {
+	return invokeNoResult(ctx, c, "StopVM", func() error {
+		return c.sdk.StopVM(id)
+	}, attrVMID.Int(id))
+}
+
+func (c *apiClient) StartVM(ctx context.Context, id int) error {
+	return invokeNoResult(ctx, c, "StartVM", func() error {
+		return c.sdk.StartVM(id)
+	}, attrVMID.Int(id))
+}
+
+func (c *apiClient) ResizeVM(ctx context.Context, id int, instanceType string) error {
+	return invokeNoResult(ctx, c, "ResizeVM", func() error {
+		return c.sdk.ResizeVM(id, instanceType)
+	}, attrVMID.Int(id))
+}
+
+func (c *apiClient) UpdateVMUserData(ctx context.Context, id int, userData string) error {
+	return invokeNoResult(ctx, c, "UpdateVMUserData", func() error {
+		return c.sdk.UpdateVMUserData(id, userData)
+	}, attrVMID.Int(id))
+}
+
+func (c *apiClient) CreateSSHKey(ctx context.Context, key *fakecloud.SSHKey) (*fakecloud.SSHKey, error) {
+	created, err := invoke(ctx, c, "CreateSSHKey", func() (*fakecloud.SSHKey, error) {
+		return c.sdk.CreateSSHKey(key)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(attrSSHKeyID.Int(created.ID))
+
+	return created, nil
+}
+
+func (c *apiClient) GetSSHKey(ctx context.Context, id int) (*fakecloud.SSHKey, error) {
+	return invoke(ctx, c, "GetSSHKey", func() (*fakecloud.SSHKey, error) {
+		return c.sdk.GetSSHKey(id)
+	}, attrSSHKeyID.Int(id))
+}
+
+func (c *apiClient) UpdateSSHKey(ctx context.Context, id int, name string) error {
+	return invokeNoResult(ctx, c, "UpdateSSHKey", func() error {
+		return c.sdk.UpdateSSHKey(id, name)
+	}, attrSSHKeyID.Int(id))
+}
+
+func (c *apiClient) DeleteSSHKey(ctx context.Context, id int) error {
+	return invokeNoResult(ctx, c, "DeleteSSHKey", func() error {
+		return c.sdk.DeleteSSHKey(id)
+	}, attrSSHKeyID.Int(id))
+}
+
+func (c *apiClient) GetImages(ctx context.Context) ([]fakecloud.Image, error) {
+	return invoke(ctx, c, "GetImages", func() ([]fakecloud.Image, error) {
+		return c.sdk.GetImages()
+	})
+}
+
+func (c *apiClient) CreateSnapshot(ctx context.Context, snapshot *fakecloud.Snapshot) (*fakecloud.Snapshot, error) {
+	created, err := invoke(ctx, c, "CreateSnapshot", func() (*fakecloud.Snapshot, error) {
+		return c.sdk.CreateSnapshot(snapshot)
+	}, attrVMID.Int(snapshot.VMID))
+	if err != nil {
+		return nil, err
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(attrSnapshotID.Int(created.ID))
+
+	return created, nil
+}
+
+func (c *apiClient) GetSnapshot(ctx context.Context, id int) (*fakecloud.Snapshot, error) {
+	return invoke(ctx, c, "GetSnapshot", func() (*fakecloud.Snapshot, error) {
+		return c.sdk.GetSnapshot(id)
+	}, attrSnapshotID.Int(id))
+}
+
+func (c *apiClient) UpdateSnapshot(ctx context.Context, id int, name string, retentionDays int) error {
+	return invokeNoResult(ctx, c, "UpdateSnapshot", func() error {
+		return c.sdk.UpdateSnapshot(id, name, retentionDays)
+	}, attrSnapshotID.Int(id))
+}
+
+func (c *apiClient) DeleteSnapshot(ctx context.Context, id int) error {
+	return invokeNoResult(ctx, c, "DeleteSnapshot", func() error {
+		return c.sdk.DeleteSnapshot(id)
+	}, attrSnapshotID.Int(id))
+}
+
+func (c *apiClient) CreateImage(ctx context.Context, image *fakecloud.Image) (*fakecloud.Image, error) {
+	created, err := invoke(ctx, c, "CreateImage", func() (*fakecloud.Image, error) {
+		return c.sdk.CreateImage(image)
+	}, attrSnapshotID.Int(image.SnapshotID))
+	if err != nil {
+		return nil, err
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(attrImageID.Int(created.ID))
+
+	return created, nil
+}
+
+func (c *apiClient) GetImage(ctx context.Context, id int) (*fakecloud.Image, error) {
+	return invoke(ctx, c, "GetImage", func() (*fakecloud.Image, error) {
+		return c.sdk.GetImage(id)
+	}, attrImageID.Int(id))
+}
+
+func (c *apiClient) UpdateImage(ctx context.Context, id int, name string, tags map[string]string) error {
+	return invokeNoResult(ctx, c, "UpdateImage", func() error {
+		return c.sdk.UpdateImage(id, name, tags)
+	}, attrImageID.Int(id))
+}
+
+func (c *apiClient) DeleteImage(ctx context.Context, id int) error {
+	return invokeNoResult(ctx, c, "DeleteImage", func() error {
+		return c.sdk.DeleteImage(id)
+	}, attrImageID.Int(id))
+}
+
+func (c *apiClient) CreateFloatingIP(ctx context.Context, ip *fakecloud.FloatingIP) (*fakecloud.FloatingIP, error) {
+	created, err := invoke(ctx, c, "CreateFloatingIP", func() (*fakecloud.FloatingIP, error) {
+		return c.sdk.CreateFloatingIP(ip)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(attrFloatingIPID.Int(created.ID))
+
+	return created, nil
+}
+
+func (c *apiClient) GetFloatingIP(ctx context.Context, id int) (*fakecloud.FloatingIP, error) {
+	return invoke(ctx, c, "GetFloatingIP", func() (*fakecloud.FloatingIP, error) {
+		return c.sdk.GetFloatingIP(id)
+	}, attrFloatingIPID.Int(id))
+}
+
+func (c *apiClient) UpdateFloatingIP(ctx context.Context, id int, description string) error {
+	return invokeNoResult(ctx, c, "UpdateFloatingIP", func() error {
+		return c.sdk.UpdateFloatingIP(id, description)
+	}, attrFloatingIPID.Int(id))
+}
+
+func (c *apiClient) DeleteFloatingIP(ctx context.Context, id int) error {
+	return invokeNoResult(ctx, c, "DeleteFloatingIP", func() error {
+		return c.sdk.DeleteFloatingIP(id)
+	}, attrFloatingIPID.Int(id))
+}
+
+func (c *apiClient) AssignFloatingIP(ctx context.Context, id int, vmID int) error {
+	return invokeNoResult(ctx, c, "AssignFloatingIP", func() error {
+		return c.sdk.AssignFloatingIP(id, vmID)
+	}, attrFloatingIPID.Int(id), attrVMID.Int(vmID))
+}
+
+func (c *apiClient) UnassignFloatingIP(ctx context.Context, id int) error {
+	return invokeNoResult(ctx, c, "UnassignFloatingIP", func() error {
+		return c.sdk.UnassignFloatingIP(id)
+	}, attrFloatingIPID.Int(id))
+}
+
+func (c *apiClient) CreateLoadBalancer(ctx context.Context, lb *fakecloud.LoadBalancer) (*fakecloud.LoadBalancer, error) {
+	created, err := invoke(ctx, c, "CreateLoadBalancer", func() (*fakecloud.LoadBalancer, error) {
+		return c.sdk.CreateLoadBalancer(lb)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(attrLoadBalancerID.Int(created.ID))
+
+	return created, nil
+}
+
+func (c *apiClient) GetLoadBalancer(ctx context.Context, id int) (*fakecloud.LoadBalancer, error) {
+	return invoke(ctx, c, "GetLoadBalancer", func() (*fakecloud.LoadBalancer, error) {
+		return c.sdk.GetLoadBalancer(id)
+	}, attrLoadBalancerID.Int(id))
+}
+
+func (c *apiClient) UpdateLoadBalancer(ctx context.Context, id int, name string, listeners []fakecloud.Listener) error {
+	return invokeNoResult(ctx, c, "UpdateLoadBalancer", func() error {
+		return c.sdk.UpdateLoadBalancer(id, name, listeners)
+	}, attrLoadBalancerID.Int(id))
+}
+
+func (c *apiClient) DeleteLoadBalancer(ctx context.Context, id int) error {
+	return invokeNoResult(ctx, c, "DeleteLoadBalancer", func() error {
+		return c.sdk.DeleteLoadBalancer(id)
+	}, attrLoadBalancerID.Int(id))
+}
+
+func (c *apiClient) AddLBTarget(ctx context.Context, lbID int, vmID int) error {
+	return invokeNoResult(ctx, c, "AddLBTarget", func() error {
+		return c.sdk.AddLBTarget(lbID, vmID)
+	}, attrLoadBalancerID.Int(lbID), attrVMID.Int(vmID))
+}
+
+func (c *apiClient) GetLBTarget(ctx context.Context, lbID int, vmID int) (*fakecloud.LBTarget, error) {
+	return invoke(ctx, c, "GetLBTarget", func() (*fakecloud.LBTarget, error) {
+		return c.sdk.GetLBTarget(lbID, vmID)
+	}, attrLoadBalancerID.Int(lbID), attrVMID.Int(vmID))
+}
+
+func (c *apiClient) RemoveLBTarget(ctx context.Context, lbID int, vmID int) error {
+	return invokeNoResult(ctx, c, "RemoveLBTarget", func() error {
+		return c.sdk.RemoveLBTarget(lbID, vmID)
+	}, attrLoadBalancerID.Int(lbID), attrVMID.Int(vmID))
+}
+
+func (c *apiClient) CreateDNSZone(ctx context.Context, zone *fakecloud.DNSZone) (*fakecloud.DNSZone, error) {
+	created, err := invoke(ctx, c, "CreateDNSZone", func() (*fakecloud.DNSZone, error) {
+		return c.sdk.CreateDNSZone(zone)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(attrDNSZoneID.Int(created.ID))
+
+	return created, nil
+}
+
+func (c *apiClient) GetDNSZone(ctx context.Context, id int) (*fakecloud.DNSZone, error) {
+	return invoke(ctx, c, "GetDNSZone", func() (*fakecloud.DNSZone, error) {
+		return c.sdk.GetDNSZone(id)
+	}, attrDNSZoneID.Int(id))
+}
+
+func (c *apiClient) GetDNSZones(ctx context.Context) ([]fakecloud.DNSZone, error) {
+	return invoke(ctx, c, "GetDNSZones", func() ([]fakecloud.DNSZone, error) {
+		return c.sdk.GetDNSZones()
+	})
+}
+
+func (c *apiClient) UpdateDNSZone(ctx context.Context, id int, description string) error {
+	return invokeNoResult(ctx, c, "UpdateDNSZone", func() error {
+		return c.sdk.UpdateDNSZone(id, description)
+	}, attrDNSZoneID.Int(id))
+}
+
+func (c *apiClient) DeleteDNSZone(ctx context.Context, id int) error {
+	return invokeNoResult(ctx, c, "DeleteDNSZone", func() error {
+		return c.sdk.DeleteDNSZone(id)
+	}, attrDNSZoneID.Int(id))
+}
+
+func (c *apiClient) CreateDNSRecord(ctx context.Context, record *fakecloud.DNSRecord) (*fakecloud.DNSRecord, error) {
+	created, err := invoke(ctx, c, "CreateDNSRecord", func() (*fakecloud.DNSRecord, error) {
+		return c.sdk.CreateDNSRecord(record)
+	}, attrDNSZoneID.Int(record.ZoneID))
+	if err != nil {
+		return nil, err
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(attrDNSRecordID.Int(created.ID))
+
+	return created, nil
+}
+
+func (c *apiClient) GetDNSRecord(ctx context.Context, zoneID int, id int) (*fakecloud.DNSRecord, error) {
+	return invoke(ctx, c, "GetDNSRecord", func() (*fakecloud.DNSRecord, error) {
+		return c.sdk.GetDNSRecord(zoneID, id)
+	}, attrDNSZoneID.Int(zoneID), attrDNSRecordID.Int(id))
+}
+
+func (c *apiClient) UpdateDNSRecord(ctx context.Context, zoneID int, id int, ttl int, values []string) error {
+	return invokeNoResult(ctx, c, "UpdateDNSRecord", func() error {
+		return c.sdk.UpdateDNSRecord(zoneID, id, ttl, values)
+	}, attrDNSZoneID.Int(zoneID), attrDNSRecordID.Int(id))
+}
+
+func (c *apiClient) DeleteDNSRecord(ctx context.Context, zoneID int, id int) error {
+	return invokeNoResult(ctx, c, "DeleteDNSRecord", func() error {
+		return c.sdk.DeleteDNSRecord(zoneID, id)
+	}, attrDNSZoneID.Int(zoneID), attrDNSRecordID.Int(id))
+}
+
+func (c *apiClient) CreateBucket(ctx context.Context, bucket *fakecloud.Bucket) (*fakecloud.Bucket, error) {
+	return invoke(ctx, c, "CreateBucket", func() (*fakecloud.Bucket, error) {
+		return c.sdk.CreateBucket(bucket)
+	}, attrBucketName.String(bucket.Name))
+}
+
+func (c *apiClient) GetBucket(ctx context.Context, name string) (*fakecloud.Bucket, error) {
+	return invoke(ctx, c, "GetBucket", func() (*fakecloud.Bucket, error) {
+		return c.sdk.GetBucket(name)
+	}, attrBucketName.String(name))
+}
+
+func (c *apiClient) UpdateBucket(ctx context.Context, bucket *fakecloud.Bucket) (*fakecloud.Bucket, error) {
+	return invoke(ctx, c, "UpdateBucket", func() (*fakecloud.Bucket, error) {
+		return c.sdk.UpdateBucket(bucket)
+	}, attrBucketName.String(bucket.Name))
+}
+
+func (c *apiClient) DeleteBucket(ctx context.Context, name string) error {
+	return invokeNoResult(ctx, c, "DeleteBucket", func() error {
+		return c.sdk.DeleteBucket(name)
+	}, attrBucketName.String(name))
+}
+
+func (c *apiClient) PutBucketObject(ctx context.Context, bucket string, key string, body []byte, contentType string) (*fakecloud.BucketObject, error) {
+	return invoke(ctx, c, "PutBucketObject", func() (*fakecloud.BucketObject, error) {
+		return c.sdk.PutBucketObject(bucket, key, body, contentType)
+	}, attrBucketName.String(bucket), attrBucketObjectKey.String(key))
+}
+
+func (c *apiClient) GetBucketObject(ctx context.Context, bucket string, key string) (*fakecloud.BucketObject, error) {
+	return invoke(ctx, c, "GetBucketObject", func() (*fakecloud.BucketObject, error) {
+		return c.sdk.GetBucketObject(bucket, key)
+	}, attrBucketName.String(bucket), attrBucketObjectKey.String(key))
+}
+
+func (c *apiClient) DeleteBucketObject(ctx context.Context, bucket string, key string) error {
+	return invokeNoResult(ctx, c, "DeleteBucketObject", func() error {
+		return c.sdk.DeleteBucketObject(bucket, key)
+	}, attrBucketName.String(bucket), attrBucketObjectKey.String(key))
+}
+
+func (c *apiClient) CreateIAMUser(ctx context.Context, user *fakecloud.IAMUser) (*fakecloud.IAMUser, error) {
+	created, err := invoke(ctx, c, "CreateIAMUser", func() (*fakecloud.IAMUser, error) {
+		return c.sdk.CreateIAMUser(user)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(attrIAMUserID.Int(created.ID))
+
+	return created, nil
+}
+
+func (c *apiClient) GetIAMUser(ctx context.Context, id int) (*fakecloud.IAMUser, error) {
+	return invoke(ctx, c, "GetIAMUser", func() (*fakecloud.IAMUser, error) {
+		return c.sdk.GetIAMUser(id)
+	}, attrIAMUserID.Int(id))
+}
+
+func (c *apiClient) UpdateIAMUser(ctx context.Context, id int, email string) error {
+	return invokeNoResult(ctx, c, "UpdateIAMUser", func() error {
+		return c.sdk.UpdateIAMUser(id, email)
+	}, attrIAMUserID.Int(id))
+}
+
+func (c *apiClient) DeleteIAMUser(ctx context.Context, id int) error {
+	return invokeNoResult(ctx, c, "DeleteIAMUser", func() error {
+		return c.sdk.DeleteIAMUser(id)
+	}, attrIAMUserID.Int(id))
+}
+
+func (c *apiClient) CreateIAMRole(ctx context.Context, role *fakecloud.IAMRole) (*fakecloud.IAMRole, error) {
+	created, err := invoke(ctx, c, "CreateIAMRole", func() (*fakecloud.IAMRole, error) {
+		return c.sdk.CreateIAMRole(role)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(attrIAMRoleID.Int(created.ID))
+
+	return created, nil
+}
+
+func (c *apiClient) GetIAMRole(ctx context.Context, id int) (*fakecloud.IAMRole, error) {
+	return invoke(ctx, c, "GetIAMRole", func() (*fakecloud.IAMRole, error) {
+		return c.sdk.GetIAMRole(id)
+	}, attrIAMRoleID.Int(id))
+}
+
+func (c *apiClient) UpdateIAMRole(ctx context.Context, role *fakecloud.IAMRole) error {
+	return invokeNoResult(ctx, c, "UpdateIAMRole", func() error {
+		return c.sdk.UpdateIAMRole(role)
+	}, attrIAMRoleID.Int(role.ID))
+}
+
+func (c *apiClient) DeleteIAMRole(ctx context.Context, id int) error {
+	return invokeNoResult(ctx, c, "DeleteIAMRole", func() error {
+		return c.sdk.DeleteIAMRole(id)
+	}, attrIAMRoleID.Int(id))
+}
+
+func (c *apiClient) CreateIAMPolicy(ctx context.Context, policy *fakecloud.IAMPolicy) (*fakecloud.IAMPolicy, error) {
+	created, err := invoke(ctx, c, "CreateIAMPolicy", func() (*fakecloud.IAMPolicy, error) {
+		return c.sdk.CreateIAMPolicy(policy)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(attrIAMPolicyID.Int(created.ID))
+
+	return created, nil
+}
+
+func (c *apiClient) GetIAMPolicy(ctx context.Context, id int) (*fakecloud.IAMPolicy, error) {
+	return invoke(ctx, c, "GetIAMPolicy", func() (*fakecloud.IAMPolicy, error) {
+		return c.sdk.GetIAMPolicy(id)
+	}, attrIAMPolicyID.Int(id))
+}
+
+func (c *apiClient) UpdateIAMPolicy(ctx context.Context, policy *fakecloud.IAMPolicy) error {
+	return invokeNoResult(ctx, c, "UpdateIAMPolicy", func() error {
+		return c.sdk.UpdateIAMPolicy(policy)
+	}, attrIAMPolicyID.Int(policy.ID))
+}
+
+func (c *apiClient) DeleteIAMPolicy(ctx context.Context, id int) error {
+	return invokeNoResult(ctx, c, "DeleteIAMPolicy", func() error {
+		return c.sdk.DeleteIAMPolicy(id)
+	}, attrIAMPolicyID.Int(id))
+}
+
+func (c *apiClient) AttachIAMPolicy(ctx context.Context, attachment *fakecloud.IAMPolicyAttachment) error {
+	return invokeNoResult(ctx, c, "AttachIAMPolicy", func() error {
+		return c.sdk.AttachIAMPolicy(attachment)
+	}, attrIAMPolicyID.Int(attachment.PolicyID))
+}
+
+func (c *apiClient) DetachIAMPolicy(ctx context.Context, attachment *fakecloud.IAMPolicyAttachment) error {
+	return invokeNoResult(ctx, c, "DetachIAMPolicy", func() error {
+		return c.sdk.DetachIAMPolicy(attachment)
+	}, attrIAMPolicyID.Int(attachment.PolicyID))
+}
+
+func (c *apiClient) GetIAMPolicyAttachments(ctx context.Context, policyID int) ([]fakecloud.IAMPolicyAttachment, error) {
+	return invoke(ctx, c, "GetIAMPolicyAttachments", func() ([]fakecloud.IAMPolicyAttachment, error) {
+		return c.sdk.GetIAMPolicyAttachments(policyID)
+	}, attrIAMPolicyID.Int(policyID))
+}
+
+func (c *apiClient) GetCallerIdentity(ctx context.Context) (*fakecloud.CallerIdentity, error) {
+	return invoke(ctx, c, "GetCallerIdentity", func() (*fakecloud.CallerIdentity, error) {
+		return c.sdk.GetCallerIdentity()
+	})
+}
+
+func (c *apiClient) CreateInstanceGroup(ctx context.Context, group *fakecloud.InstanceGroup) (*fakecloud.InstanceGroup, error) {
+	created, err := invoke(ctx, c, "CreateInstanceGroup", func() (*fakecloud.InstanceGroup, error) {
+		return c.sdk.CreateInstanceGroup(group)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(attrInstanceGroupID.Int(created.ID))
+
+	return created, nil
+}
+
+func (c *apiClient) GetInstanceGroup(ctx context.Context, id int) (*fakecloud.InstanceGroup, error) {
+	return invoke(ctx, c, "GetInstanceGroup", func() (*fakecloud.InstanceGroup, error) {
+		return c.sdk.GetInstanceGroup(id)
+	}, attrInstanceGroupID.Int(id))
+}
+
+func (c *apiClient) UpdateInstanceGroup(ctx context.Context, group *fakecloud.InstanceGroup) (*fakecloud.InstanceGroup, error) {
+	return invoke(ctx, c, "UpdateInstanceGroup", func() (*fakecloud.InstanceGroup, error) {
+		return c.sdk.UpdateInstanceGroup(group)
+	}, attrInstanceGroupID.Int(group.ID))
+}
+
+func (c *apiClient) DeleteInstanceGroup(ctx context.Context, id int) error {
+	return invokeNoResult(ctx, c, "DeleteInstanceGroup", func() error {
+		return c.sdk.DeleteInstanceGroup(id)
+	}, attrInstanceGroupID.Int(id))
+}
+
+func (c *apiClient) AddInstanceGroupInstances(ctx context.Context, id int, count int) ([]int, error) {
+	return invoke(ctx, c, "AddInstanceGroupInstances", func() ([]int, error) {
+		return c.sdk.AddInstanceGroupInstances(id, count)
+	}, attrInstanceGroupID.Int(id))
+}
+
+func (c *apiClient) RemoveInstanceGroupInstances(ctx context.Context, id int, vmIDs []int) error {
+	return invokeNoResult(ctx, c, "RemoveInstanceGroupInstances", func() error {
+		return c.sdk.RemoveInstanceGroupInstances(id, vmIDs)
+	}, attrInstanceGroupID.Int(id))
+}
+
+func (c *apiClient) CreateAutoscalingPolicy(ctx context.Context, policy *fakecloud.AutoscalingPolicy) (*fakecloud.AutoscalingPolicy, error) {
+	created, err := invoke(ctx, c, "CreateAutoscalingPolicy", func() (*fakecloud.AutoscalingPolicy, error) {
+		return c.sdk.CreateAutoscalingPolicy(policy)
+	}, attrInstanceGroupID.Int(policy.InstanceGroupID))
+	if err != nil {
+		return nil, err
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(attrAutoscalingPolicyID.Int(created.ID))
+
+	return created, nil
+}
+
+func (c *apiClient) GetAutoscalingPolicy(ctx context.Context, id int) (*fakecloud.AutoscalingPolicy, error) {
+	return invoke(ctx, c, "GetAutoscalingPolicy", func() (*fakecloud.AutoscalingPolicy, error) {
+		return c.sdk.GetAutoscalingPolicy(id)
+	}, attrAutoscalingPolicyID.Int(id))
+}
+
+func (c *apiClient) UpdateAutoscalingPolicy(ctx context.Context, policy *fakecloud.AutoscalingPolicy) (*fakecloud.AutoscalingPolicy, error) {
+	return invoke(ctx, c, "UpdateAutoscalingPolicy", func() (*fakecloud.AutoscalingPolicy, error) {
+		return c.sdk.UpdateAutoscalingPolicy(policy)
+	}, attrAutoscalingPolicyID.Int(policy.ID))
+}
+
+func (c *apiClient) DeleteAutoscalingPolicy(ctx context.Context, id int) error {
+	return invokeNoResult(ctx, c, "DeleteAutoscalingPolicy", func() error {
+		return c.sdk.DeleteAutoscalingPolicy(id)
+	}, attrAutoscalingPolicyID.Int(id))
+}
+
+func (c *apiClient) CreateProject(ctx context.Context, project *fakecloud.Project) (*fakecloud.Project, error) {
+	return invoke(ctx, c, "CreateProject", func() (*fakecloud.Project, error) {
+		return c.sdk.CreateProject(project)
+	}, attrProjectName.String(project.Name))
+}
+
+func (c *apiClient) GetProject(ctx context.Context, name string) (*fakecloud.Project, error) {
+	return invoke(ctx, c, "GetProject", func() (*fakecloud.Project, error) {
+		return c.sdk.GetProject(name)
+	}, attrProjectName.String(name))
+}
+
+func (c *apiClient) UpdateProject(ctx context.Context, project *fakecloud.Project) (*fakecloud.Project, error) {
+	return invoke(ctx, c, "UpdateProject", func() (*fakecloud.Project, error) {
+		return c.sdk.UpdateProject(project)
+	}, attrProjectName.String(project.Name))
+}
+
+func (c *apiClient) DeleteProject(ctx context.Context, name string) error {
+	return invokeNoResult(ctx, c, "DeleteProject", func() error {
+		return c.sdk.DeleteProject(name)
+	}, attrProjectName.String(name))
+}
+
+func (c *apiClient) CreateKubernetesCluster(ctx context.Context, cluster *fakecloud.KubernetesCluster) (*fakecloud.KubernetesCluster, error) {
+	created, err := invoke(ctx, c, "CreateKubernetesCluster", func() (*fakecloud.KubernetesCluster, error) {
+		return c.sdk.CreateKubernetesCluster(cluster)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(attrKubernetesClusterID.Int(created.ID))
+
+	return created, nil
+}
+
+func (c *apiClient) GetKubernetesCluster(ctx context.Context, id int) (*fakecloud.KubernetesCluster, error) {
+	return invoke(ctx, c, "GetKubernetesCluster", func() (*fakecloud.KubernetesCluster, error) {
+		return c.sdk.GetKubernetesCluster(id)
+	}, attrKubernetesClusterID.Int(id))
+}
+
+func (c *apiClient) UpdateKubernetesCluster(ctx context.Context, id int, version string) error {
+	return invokeNoResult(ctx, c, "UpdateKubernetesCluster", func() error {
+		return c.sdk.UpdateKubernetesCluster(id, version)
+	}, attrKubernetesClusterID.Int(id))
+}
+
+func (c *apiClient) DeleteKubernetesCluster(ctx context.Context, id int) error {
+	return invokeNoResult(ctx, c, "DeleteKubernetesCluster", func() error {
+		return c.sdk.DeleteKubernetesCluster(id)
+	}, attrKubernetesClusterID.Int(id))
+}
+
+func (c *apiClient) CreateKubernetesNodePool(ctx context.Context, pool *fakecloud.KubernetesNodePool) (*fakecloud.KubernetesNodePool, error) {
+	created, err := invoke(ctx, c, "CreateKubernetesNodePool", func() (*fakecloud.KubernetesNodePool, error) {
+		return c.sdk.CreateKubernetesNodePool(pool)
+	}, attrKubernetesClusterID.Int(pool.ClusterID))
+	if err != nil {
+		return nil, err
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(attrKubernetesNodePoolID.Int(created.ID))
+
+	return created, nil
+}
+
+func (c *apiClient) GetKubernetesNodePool(ctx context.Context, clusterID int, id int) (*fakecloud.KubernetesNodePool, error) {
+	return invoke(ctx, c, "GetKubernetesNodePool", func() (*fakecloud.KubernetesNodePool, error) {
+		return c.sdk.GetKubernetesNodePool(clusterID, id)
+	}, attrKubernetesClusterID.Int(clusterID), attrKubernetesNodePoolID.Int(id))
+}
+
+func (c *apiClient) UpdateKubernetesNodePool(ctx context.Context, pool *fakecloud.KubernetesNodePool) error {
+	return invokeNoResult(ctx, c, "UpdateKubernetesNodePool", func() error {
+		return c.sdk.UpdateKubernetesNodePool(pool)
+	}, attrKubernetesClusterID.Int(pool.ClusterID), attrKubernetesNodePoolID.Int(pool.ID))
+}
+
+func (c *apiClient) DeleteKubernetesNodePool(ctx context.Context, clusterID int, id int) error {
+	return invokeNoResult(ctx, c, "DeleteKubernetesNodePool", func() error {
+		return c.sdk.DeleteKubernetesNodePool(clusterID, id)
+	}, attrKubernetesClusterID.Int(clusterID), attrKubernetesNodePoolID.Int(id))
+}
+
+func (c *apiClient) CreateDatabaseInstance(ctx context.Context, instance *fakecloud.DatabaseInstance) (*fakecloud.DatabaseInstance, error) {
+	created, err := invoke(ctx, c, "CreateDatabaseInstance", func() (*fakecloud.DatabaseInstance, error) {
+		return c.sdk.CreateDatabaseInstance(instance)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(attrDatabaseInstanceID.Int(created.ID))
+
+	return created, nil
+}
+
+func (c *apiClient) GetDatabaseInstance(ctx context.Context, id int) (*fakecloud.DatabaseInstance, error) {
+	return invoke(ctx, c, "GetDatabaseInstance", func() (*fakecloud.DatabaseInstance, error) {
+		return c.sdk.GetDatabaseInstance(id)
+	}, attrDatabaseInstanceID.Int(id))
+}
+
+func (c *apiClient) UpdateDatabaseInstance(ctx context.Context, instance *fakecloud.DatabaseInstance) error {
+	return invokeNoResult(ctx, c, "UpdateDatabaseInstance", func() error {
+		return c.sdk.UpdateDatabaseInstance(instance)
+	}, attrDatabaseInstanceID.Int(instance.ID))
+}
+
+func (c *apiClient) DeleteDatabaseInstance(ctx context.Context, id int) error {
+	return invokeNoResult(ctx, c, "DeleteDatabaseInstance", func() error {
+		return c.sdk.DeleteDatabaseInstance(id)
+	}, attrDatabaseInstanceID.Int(id))
+}
+
+func (c *apiClient) CreateDatabaseUser(ctx context.Context, user *fakecloud.DatabaseUser) (*fakecloud.DatabaseUser, error) {
+	created, err := invoke(ctx, c, "CreateDatabaseUser", func() (*fakecloud.DatabaseUser, error) {
+		return c.sdk.CreateDatabaseUser(user)
+	}, attrDatabaseInstanceID.Int(user.InstanceID))
+	if err != nil {
+		return nil, err
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(attrDatabaseUserID.Int(created.ID))
+
+	return created, nil
+}
+
+func (c *apiClient) GetDatabaseUser(ctx context.Context, instanceID int, id int) (*fakecloud.DatabaseUser, error) {
+	return invoke(ctx, c, "GetDatabaseUser", func() (*fakecloud.DatabaseUser, error) {
+		return c.sdk.GetDatabaseUser(instanceID, id)
+	}, attrDatabaseInstanceID.Int(instanceID), attrDatabaseUserID.Int(id))
+}
+
+func (c *apiClient) UpdateDatabaseUserPassword(ctx context.Context, instanceID int, id int, password string) error {
+	return invokeNoResult(ctx, c, "UpdateDatabaseUserPassword", func() error {
+		return c.sdk.UpdateDatabaseUserPassword(instanceID, id, password)
+	}, attrDatabaseInstanceID.Int(instanceID), attrDatabaseUserID.Int(id))
+}
+
+func (c *apiClient) DeleteDatabaseUser(ctx context.Context, instanceID int, id int) error {
+	return invokeNoResult(ctx, c, "DeleteDatabaseUser", func() error {
+		return c.sdk.DeleteDatabaseUser(instanceID, id)
+	}, attrDatabaseInstanceID.Int(instanceID), attrDatabaseUserID.Int(id))
+}
+
+func (c *apiClient) CreateSecret(ctx context.Context, secret *fakecloud.Secret) (*fakecloud.Secret, error) {
+	created, err := invoke(ctx, c, "CreateSecret", func() (*fakecloud.Secret, error) {
+		return c.sdk.CreateSecret(secret)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(attrSecretID.Int(created.ID))
+
+	return created, nil
+}
+
+func (c *apiClient) GetSecret(ctx context.Context, id int) (*fakecloud.Secret, error) {
+	return invoke(ctx, c, "GetSecret", func() (*fakecloud.Secret, error) {
+		return c.sdk.GetSecret(id)
+	}, attrSecretID.Int(id))
+}
+
+func (c *apiClient) UpdateSecret(ctx context.Context, secret *fakecloud.Secret) error {
+	return invokeNoResult(ctx, c, "UpdateSecret", func() error {
+		return c.sdk.UpdateSecret(secret)
+	}, attrSecretID.Int(secret.ID))
+}
+
+func (c *apiClient) DeleteSecret(ctx context.Context, id int) error {
+	return invokeNoResult(ctx, c, "DeleteSecret", func() error {
+		return c.sdk.DeleteSecret(id)
+	}, attrSecretID.Int(id))
+}
+
+func (c *apiClient) AddSecretVersion(ctx context.Context, secretID int, value string) (*fakecloud.SecretVersion, error) {
+	created, err := invoke(ctx, c, "AddSecretVersion", func() (*fakecloud.SecretVersion, error) {
+		return c.sdk.AddSecretVersion(secretID, value)
+	}, attrSecretID.Int(secretID))
+	if err != nil {
+		return nil, err
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(attrSecretVersionID.Int(created.ID))
+
+	return created, nil
+}
+
+func (c *apiClient) GetSecretVersion(ctx context.Context, secretID int, id int) (*fakecloud.SecretVersion, error) {
+	return invoke(ctx, c, "GetSecretVersion", func() (*fakecloud.SecretVersion, error) {
+		return c.sdk.GetSecretVersion(secretID, id)
+	}, attrSecretID.Int(secretID), attrSecretVersionID.Int(id))
+}
+
+func (c *apiClient) AccessSecretVersion(ctx context.Context, secretID int, version int) (*fakecloud.SecretVersion, error) {
+	return invoke(ctx, c, "AccessSecretVersion", func() (*fakecloud.SecretVersion, error) {
+		return c.sdk.AccessSecretVersion(secretID, version)
+	}, attrSecretID.Int(secretID))
+}
+
+func (c *apiClient) DestroySecretVersion(ctx context.Context, secretID int, id int) error {
+	return invokeNoResult(ctx, c, "DestroySecretVersion", func() error {
+		return c.sdk.DestroySecretVersion(secretID, id)
+	}, attrSecretID.Int(secretID), attrSecretVersionID.Int(id))
+}
+
+func (c *apiClient) CreateFirewallPolicy(ctx context.Context, policy *fakecloud.FirewallPolicy) (*fakecloud.FirewallPolicy, error) {
+	created, err := invoke(ctx, c, "CreateFirewallPolicy", func() (*fakecloud.FirewallPolicy, error) {
+		return c.sdk.CreateFirewallPolicy(policy)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(attrFirewallPolicyID.Int(created.ID))
+
+	return created, nil
+}
+
+func (c *apiClient) GetFirewallPolicy(ctx context.Context, id int) (*fakecloud.FirewallPolicy, error) {
+	return invoke(ctx, c, "GetFirewallPolicy", func() (*fakecloud.FirewallPolicy, error) {
+		return c.sdk.GetFirewallPolicy(id)
+	}, attrFirewallPolicyID.Int(id))
+}
+
+func (c *apiClient) UpdateFirewallPolicy(ctx context.Context, policy *fakecloud.FirewallPolicy) error {
+	return invokeNoResult(ctx, c, "UpdateFirewallPolicy", func() error {
+		return c.sdk.UpdateFirewallPolicy(policy)
+	}, attrFirewallPolicyID.Int(policy.ID))
+}
+
+func (c *apiClient) DeleteFirewallPolicy(ctx context.Context, id int) error {
+	return invokeNoResult(ctx, c, "DeleteFirewallPolicy", func() error {
+		return c.sdk.DeleteFirewallPolicy(id)
+	}, attrFirewallPolicyID.Int(id))
+}
+
+func (c *apiClient) AttachFirewallPolicy(ctx context.Context, policyID int, networkID int) error {
+	return invokeNoResult(ctx, c, "AttachFirewallPolicy", func() error {
+		return c.sdk.AttachFirewallPolicy(policyID, networkID)
+	}, attrFirewallPolicyID.Int(policyID), attrNetworkID.Int(networkID))
+}
+
+func (c *apiClient) GetFirewallPolicyAttachment(ctx context.Context, policyID int, networkID int) (*fakecloud.FirewallPolicyAttachment, error) {
+	return invoke(ctx, c, "GetFirewallPolicyAttachment", func() (*fakecloud.FirewallPolicyAttachment, error) {
+		return c.sdk.GetFirewallPolicyAttachment(policyID, networkID)
+	}, attrFirewallPolicyID.Int(policyID), attrNetworkID.Int(networkID))
+}
+
+func (c *apiClient) DetachFirewallPolicy(ctx context.Context, policyID int, networkID int) error {
+	return invokeNoResult(ctx, c, "DetachFirewallPolicy", func() error {
+		return c.sdk.DetachFirewallPolicy(policyID, networkID)
+	}, attrFirewallPolicyID.Int(policyID), attrNetworkID.Int(networkID))
+}
+
+func (c *apiClient) CreateBackupPolicy(ctx context.Context, policy *fakecloud.BackupPolicy) (*fakecloud.BackupPolicy, error) {
+	created, err := invoke(ctx, c, "CreateBackupPolicy", func() (*fakecloud.BackupPolicy, error) {
+		return c.sdk.CreateBackupPolicy(policy)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(attrBackupPolicyID.Int(created.ID))
+
+	return created, nil
+}
+
+func (c *apiClient) GetBackupPolicy(ctx context.Context, id int) (*fakecloud.BackupPolicy, error) {
+	return invoke(ctx, c, "GetBackupPolicy", func() (*fakecloud.BackupPolicy, error) {
+		return c.sdk.GetBackupPolicy(id)
+	}, attrBackupPolicyID.Int(id))
+}
+
+func (c *apiClient) UpdateBackupPolicy(ctx context.Context, policy *fakecloud.BackupPolicy) error {
+	return invokeNoResult(ctx, c, "UpdateBackupPolicy", func() error {
+		return c.sdk.UpdateBackupPolicy(policy)
+	}, attrBackupPolicyID.Int(policy.ID))
+}
+
+func (c *apiClient) DeleteBackupPolicy(ctx context.Context, id int) error {
+	return invokeNoResult(ctx, c, "DeleteBackupPolicy", func() error {
+		return c.sdk.DeleteBackupPolicy(id)
+	}, attrBackupPolicyID.Int(id))
+}
+
+func (c *apiClient) AttachBackupPolicy(ctx context.Context, policyID int, vmID int) error {
+	return invokeNoResult(ctx, c, "AttachBackupPolicy", func() error {
+		return c.sdk.AttachBackupPolicy(policyID, vmID)
+	}, attrBackupPolicyID.Int(policyID), attrVMID.Int(vmID))
+}
+
+func (c *apiClient) GetBackupPolicyAttachment(ctx context.Context, policyID int, vmID int) (*fakecloud.BackupPolicyAttachment, error) {
+	return invoke(ctx, c, "GetBackupPolicyAttachment", func() (*fakecloud.BackupPolicyAttachment, error) {
+		return c.sdk.GetBackupPolicyAttachment(policyID, vmID)
+	}, attrBackupPolicyID.Int(policyID), attrVMID.Int(vmID))
+}
+
+func (c *apiClient) DetachBackupPolicy(ctx context.Context, policyID int, vmID int) error {
+	return invokeNoResult(ctx, c, "DetachBackupPolicy", func() error {
+		return c.sdk.DetachBackupPolicy(policyID, vmID)
+	}, attrBackupPolicyID.Int(policyID), attrVMID.Int(vmID))
+}
+
+// GetBackups lists the backups of a VM, of a backup policy, or of both when
+// vmID or policyID is 0.
+func (c *apiClient) GetBackups(ctx context.Context, vmID int, policyID int) ([]fakecloud.Backup, error) {
+	return invoke(ctx, c, "GetBackups", func() ([]fakecloud.Backup, error) {
+		return c.sdk.GetBackups(vmID, policyID)
+	}, attrVMID.Int(vmID), attrBackupPolicyID.Int(policyID))
+}
@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAPIClientConcurrencyLimit(t *testing.T) {
+	tracer, _ := newTestTracer(t)
+	client := newAPIClient(nil, tracer, apiLimits{ConcurrentRequests: 2})
+
+	var inFlight, peak int32
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_ = invokeNoResult(context.Background(), client, "GetVM", func() error {
+				n := atomic.AddInt32(&inFlight, 1)
+				for {
+					p := atomic.LoadInt32(&peak)
+					if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+						break
+					}
+				}
+
+				time.Sleep(10 * time.Millisecond)
+				atomic.AddInt32(&inFlight, -1)
+
+				return nil
+			})
+		}()
+	}
+
+	wg.Wait()
+
+	if peak > 2 {
+		t.Errorf("expected at most 2 concurrent calls, got %d", peak)
+	}
+}
+
+func TestAPIClientRateLimit(t *testing.T) {
+	tracer, exporter := newTestTracer(t)
+	client := newAPIClient(nil, tracer, apiLimits{RequestsPerSecond: 1})
+
+	// The bucket starts full, so only the second call has to wait.
+	for i := 0; i < 2; i++ {
+		if err := invokeNoResult(context.Background(), client, "GetVMs", func() error { return nil }); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	if _, ok := spanAttribute(spans[0], attrThrottleWait); ok {
+		t.Errorf("expected first call not to be throttled")
+	}
+	if v, ok := spanAttribute(spans[1], attrThrottleWait); !ok || v.AsInt64() < 500 {
+		t.Errorf("expected second call to wait for a token, got %v", v.Emit())
+	}
+}
+
+func TestAPIClientLimitHonoursContext(t *testing.T) {
+	tracer, _ := newTestTracer(t)
+	client := newAPIClient(nil, tracer, apiLimits{ConcurrentRequests: 1})
+
+	// Hold the only slot so the next call has to wait for it.
+	_, release, err := client.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	called := false
+	err = invokeNoResult(ctx, client, "DeleteVM", func() error {
+		called = true
+		return nil
+	})
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+	if called {
+		t.Error("expected the call not to be made")
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// cronSearchYears bounds the search for the next run of a cron schedule. A
+// schedule on February 29 runs at least once in any five years.
+const cronSearchYears = 5
+
+// cronDescriptors are the shorthands accepted in place of the five fields.
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var cronMonthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var cronWeekdayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+// cronSchedule is a parsed cron expression. Each field is a bit set of the
+// values it matches.
+type cronSchedule struct {
+	minutes  uint64
+	hours    uint64
+	days     uint64
+	months   uint64
+	weekdays uint64
+
+	// As in standard cron, a day matches either the day of month or the day
+	// of week when both are restricted, and both otherwise.
+	anyDay     bool
+	anyWeekday bool
+}
+
+// parseCron parses a standard five field cron expression, "<minute> <hour>
+// <day of month> <month> <day of week>", or one of the @daily style
+// shorthands. Fields accept *, lists, ranges and steps, months and days of
+// week also accept their three letter English names. Schedules are in UTC.
+func parseCron(expr string) (cronSchedule, error) {
+	if descriptor, ok := cronDescriptors[strings.ToLower(strings.TrimSpace(expr))]; ok {
+		expr = descriptor
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return cronSchedule{}, fmt.Errorf("invalid cron expression %q: expected 5 fields <minute> <hour> <day of month> <month> <day of week>, got %d", expr, len(fields))
+	}
+
+	var schedule cronSchedule
+	var err error
+
+	if schedule.minutes, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return cronSchedule{}, fmt.Errorf("invalid cron expression %q: minute: %w", expr, err)
+	}
+
+	if schedule.hours, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return cronSchedule{}, fmt.Errorf("invalid cron expression %q: hour: %w", expr, err)
+	}
+
+	if schedule.days, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return cronSchedule{}, fmt.Errorf("invalid cron expression %q: day of month: %w", expr, err)
+	}
+
+	if schedule.months, err = parseCronField(fields[3], 1, 12, cronMonthNames); err != nil {
+		return cronSchedule{}, fmt.Errorf("invalid cron expression %q: month: %w", expr, err)
+	}
+
+	if schedule.weekdays, err = parseCronField(fields[4], 0, 7, cronWeekdayNames); err != nil {
+		return cronSchedule{}, fmt.Errorf("invalid cron expression %q: day of week: %w", expr, err)
+	}
+
+	// Both 0 and 7 are Sunday.
+	if schedule.weekdays&(1<<7) != 0 {
+		schedule.weekdays = schedule.weekdays&^(1<<7) | 1
+	}
+
+	schedule.anyDay = strings.HasPrefix(fields[2], "*")
+	schedule.anyWeekday = strings.HasPrefix(fields[4], "*")
+
+	// Catch schedules such as February 30 that are valid field by field.
+	if _, ok := schedule.next(time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)); !ok {
+		return cronSchedule{}, fmt.Errorf("invalid cron expression %q: the schedule never runs", expr)
+	}
+
+	return schedule, nil
+}
+
+// parseCronField parses a comma separated list of values, ranges and steps
+// between min and max into a bit set.
+func parseCronField(field string, min int, max int, names map[string]int) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(field, ",") {
+		values, stepValue, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepValue); err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step %q", stepValue)
+			}
+		}
+
+		var low, high int
+
+		switch from, to, isRange := strings.Cut(values, "-"); {
+		case values == "*":
+			low, high = min, max
+		case isRange:
+			var err error
+			if low, err = parseCronValue(from, names); err != nil {
+				return 0, err
+			}
+			if high, err = parseCronValue(to, names); err != nil {
+				return 0, err
+			}
+		default:
+			var err error
+			if low, err = parseCronValue(values, names); err != nil {
+				return 0, err
+			}
+
+			// A single value with a step, e.g. 5/15, runs from the value
+			// to the end of the field.
+			high = low
+			if hasStep {
+				high = max
+			}
+		}
+
+		if low < min || high > max || low > high {
+			return 0, fmt.Errorf("%q is out of range %d-%d", part, min, max)
+		}
+
+		for value := low; value <= high; value += step {
+			bits |= 1 << value
+		}
+	}
+
+	return bits, nil
+}
+
+// parseCronValue parses a number or a name of names.
+func parseCronValue(value string, names map[string]int) (int, error) {
+	if n, ok := names[strings.ToLower(value)]; ok {
+		return n, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", value)
+	}
+
+	return n, nil
+}
+
+// next returns the first time the schedule runs strictly after after, false
+// when it does not run within cronSearchYears.
+func (s cronSchedule) next(after time.Time) (time.Time, bool) {
+	t := after.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(cronSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case s.months&(1<<t.Month()) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case s.hours&(1<<t.Hour()) == 0:
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case s.minutes&(1<<t.Minute()) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// dayMatches reports whether the schedule runs on the day of t.
+func (s cronSchedule) dayMatches(t time.Time) bool {
+	day := s.days&(1<<t.Day()) != 0
+	weekday := s.weekdays&(1<<t.Weekday()) != 0
+
+	if s.anyDay || s.anyWeekday {
+		return day && weekday
+	}
+
+	return day || weekday
+}
+
+var _ validator.String = cronValidator{}
+
+// cronValidator validates that a string is a cron expression that runs.
+type cronValidator struct{}
+
+func (v cronValidator) Description(ctx context.Context) string {
+	return "value must be a cron expression, e.g. 0 3 * * *"
+}
+
+func (v cronValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v cronValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := parseCron(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Cron Expression", err.Error())
+	}
+}
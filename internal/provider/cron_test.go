@@ -0,0 +1,156 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseCron(t *testing.T) {
+	cases := map[string]struct {
+		expr string
+		err  bool
+	}{
+		"every minute":          {expr: "* * * * *"},
+		"lists ranges steps":    {expr: "0,30 8-18/2 1-15 */3 1-5"},
+		"names":                 {expr: "0 0 * JAN-jun mon,FRI"},
+		"sunday as 7":           {expr: "0 0 * * 7"},
+		"descriptor":            {expr: "@daily"},
+		"leap day":              {expr: "0 0 29 2 *"},
+		"too few fields":        {expr: "* * * *", err: true},
+		"too many fields":       {expr: "0 * * * * *", err: true},
+		"empty":                 {expr: "", err: true},
+		"unknown descriptor":    {expr: "@every 5m", err: true},
+		"minute out of range":   {expr: "60 * * * *", err: true},
+		"hour out of range":     {expr: "* 24 * * *", err: true},
+		"day out of range":      {expr: "* * 0 * *", err: true},
+		"month out of range":    {expr: "* * * 13 *", err: true},
+		"weekday out of range":  {expr: "* * * * 8", err: true},
+		"zero step":             {expr: "*/0 * * * *", err: true},
+		"reversed range":        {expr: "5-1 * * * *", err: true},
+		"not a number":          {expr: "a * * * *", err: true},
+		"name in numeric field": {expr: "0 0 mon * *", err: true},
+		"never runs":            {expr: "0 0 30 2 *", err: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseCron(tc.expr)
+			if tc.err && err == nil {
+				t.Fatal("expected error, got none")
+			}
+
+			if !tc.err && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	// A Wednesday of a leap year.
+	after := time.Date(2024, time.February, 28, 10, 30, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		expr     string
+		expected time.Time
+	}{
+		"every quarter": {
+			expr:     "*/15 * * * *",
+			expected: time.Date(2024, time.February, 28, 10, 45, 0, 0, time.UTC),
+		},
+		"step from value": {
+			expr:     "5/20 * * * *",
+			expected: time.Date(2024, time.February, 28, 10, 45, 0, 0, time.UTC),
+		},
+		"hourly": {
+			expr:     "@hourly",
+			expected: time.Date(2024, time.February, 28, 11, 0, 0, 0, time.UTC),
+		},
+		"strictly after": {
+			expr:     "30 10 * * *",
+			expected: time.Date(2024, time.February, 29, 10, 30, 0, 0, time.UTC),
+		},
+		"leap day": {
+			expr:     "0 0 29 2 *",
+			expected: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+		},
+		"day list": {
+			expr:     "0 12 1,15 * *",
+			expected: time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC),
+		},
+		"skips short months": {
+			expr:     "0 0 31 * *",
+			expected: time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC),
+		},
+		"month step": {
+			expr:     "0 0 1 jan-dec/2 *",
+			expected: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"weekday": {
+			expr:     "0 0 * * mon",
+			expected: time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC),
+		},
+		"sunday as 7": {
+			expr:     "0 0 * * 7",
+			expected: time.Date(2024, time.March, 3, 0, 0, 0, 0, time.UTC),
+		},
+		"day of month or weekday": {
+			expr:     "0 0 1 * 4",
+			expected: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+		},
+		"next year": {
+			expr:     "0 0 1 1 *",
+			expected: time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			schedule, err := parseCron(tc.expr)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			next, ok := schedule.next(after)
+			if !ok {
+				t.Fatal("expected a next run, got none")
+			}
+
+			if !next.Equal(tc.expected) {
+				t.Errorf("expected %s, got %s", tc.expected, next)
+			}
+		})
+	}
+}
+
+func TestNextRunAt(t *testing.T) {
+	now := time.Date(2024, time.February, 28, 10, 30, 0, 0, time.FixedZone("CET", 3600))
+
+	cases := map[string]struct {
+		schedule string
+		expected types.String
+	}{
+		"utc": {
+			schedule: "0 12 * * *",
+			expected: types.StringValue("2024-02-28T12:00:00Z"),
+		},
+		"invalid": {
+			schedule: "0 12 * *",
+			expected: types.StringNull(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expected, nextRunAt(tc.schedule, now)); diff != "" {
+				t.Errorf("unexpected next run (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
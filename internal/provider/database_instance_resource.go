@@ -0,0 +1,478 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DatabaseInstanceResource{}
+var _ resource.ResourceWithImportState = &DatabaseInstanceResource{}
+var _ resource.ResourceWithModifyPlan = &DatabaseInstanceResource{}
+
+// databaseEngines are the database engines offered by Fakecloud.
+var databaseEngines = []string{"mysql", "postgres"}
+
+// backupWindowRegexp matches a daily backup window in UTC, e.g. 02:00-03:00.
+var backupWindowRegexp = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]-([01][0-9]|2[0-3]):[0-5][0-9]$`)
+
+// Statuses of database instances.
+const (
+	databaseStatusCreating  = "creating"
+	databaseStatusAvailable = "available"
+	databaseStatusModifying = "modifying"
+)
+
+// Default timeouts of database instance operations when the configuration
+// does not set them. An update may upgrade the engine version, which takes
+// long on large databases.
+const (
+	defaultDatabaseInstanceCreateTimeout = 60 * time.Minute
+	defaultDatabaseInstanceUpdateTimeout = 180 * time.Minute
+)
+
+func NewDatabaseInstanceResource() resource.Resource {
+	return &DatabaseInstanceResource{}
+}
+
+// DatabaseInstanceResource defines the resource implementation.
+type DatabaseInstanceResource struct {
+	providerData *providerData
+}
+
+// DatabaseInstanceResourceModel describes the resource data model.
+type DatabaseInstanceResourceModel struct {
+	ID            types.Int64  `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	Region        types.String `tfsdk:"region"`
+	Project       types.String `tfsdk:"project"`
+	Engine        types.String `tfsdk:"engine"`
+	Version       types.String `tfsdk:"version"`
+	Size          types.String `tfsdk:"size"`
+	StorageGB     types.Int64  `tfsdk:"storage_gb"`
+	BackupWindow  types.String `tfsdk:"backup_window"`
+	Endpoint      types.String `tfsdk:"endpoint"`
+	AdminUsername types.String `tfsdk:"admin_username"`
+	AdminPassword types.String `tfsdk:"admin_password"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *DatabaseInstanceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_database_instance"
+}
+
+func (r *DatabaseInstanceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Managed database server. The provider waits for the instance to be available after creating or modifying it. " +
+			"Import with `<id>`, prefixed with `<region>/` outside the provider region, or `<project>/<region>/` outside the provider project. " +
+			"The admin password is only returned when the instance is created, it stays null after an import.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Database instance identifier",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the database instance. Changing it forces a new database instance to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Region the database instance is created in. Defaults to the provider region. Changing it forces a new database instance to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project the database instance belongs to. Defaults to the provider project. Changing it forces a new database instance to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"engine": schema.StringAttribute{
+				MarkdownDescription: "Database engine, `mysql` or `postgres`. Changing it forces a new database instance to be created.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(databaseEngines...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "Major version of the engine, e.g. `16` for `postgres` or `8.0` for `mysql`. " +
+					"Changing it upgrades the instance in place, the API rejects downgrades.",
+				Required: true,
+			},
+			"size": schema.StringAttribute{
+				MarkdownDescription: "Size of the database server, one of the instance type sizes: `small`, `medium`, `large`, `xlarge` or `2xlarge`. " +
+					"Changing it resizes the instance in place.",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(sortedKeys(instanceSizes)...),
+				},
+			},
+			"storage_gb": schema.Int64Attribute{
+				MarkdownDescription: "Storage of the instance in GB, between 10 and 16384. Growing it is done in place, " +
+					"shrinking it forces a new database instance to be created.",
+				Required: true,
+				Validators: []validator.Int64{
+					int64validator.Between(10, 16384),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplaceIf(
+						storageShrinkRequiresReplace,
+						"Shrinking the storage forces a new database instance to be created.",
+						"Shrinking the storage forces a new database instance to be created.",
+					),
+				},
+			},
+			"backup_window": schema.StringAttribute{
+				MarkdownDescription: "Daily window of the automated backups in UTC, e.g. `02:00-03:00`. Chosen by Fakecloud when omitted.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(backupWindowRegexp, "must be a window in UTC, e.g. 02:00-03:00"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "Address of the database server, `<host>:<port>`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"admin_username": schema.StringAttribute{
+				MarkdownDescription: "Name of the administrator user.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"admin_password": schema.StringAttribute{
+				MarkdownDescription: "Password of the administrator user, generated by Fakecloud when the instance is created.",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+// storageShrinkRequiresReplace replaces the database instance when its
+// storage is planned to shrink, which the API cannot do in place.
+func storageShrinkRequiresReplace(ctx context.Context, req planmodifier.Int64Request, resp *int64planmodifier.RequiresReplaceIfFuncResponse) {
+	if req.StateValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+
+	resp.RequiresReplace = req.PlanValue.ValueInt64() < req.StateValue.ValueInt64()
+}
+
+func (r *DatabaseInstanceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *DatabaseInstanceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.planScope(ctx, req.Config, req.State, req.Plan, resp)
+}
+
+func (r *DatabaseInstanceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DatabaseInstanceResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultDatabaseInstanceCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	instance, err := client.CreateDatabaseInstance(ctx, data.expand())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to create database instance", err.Error())
+		return
+	}
+
+	data.ID = types.Int64Value(int64(instance.ID))
+	saveCreated(ctx, resp, client, data.ID)
+
+	// The generated password is only returned on creation, it is saved along
+	// with the identifier so that a failed wait does not lose it.
+	data.AdminPassword = types.StringValue(instance.AdminPassword)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("admin_password"), data.AdminPassword)...)
+
+	tflog.Trace(ctx, "created a database instance")
+
+	instance, err = waitDatabaseInstanceAvailable(ctx, databaseInstanceRefresh(client, instance.ID), databaseStatusCreating, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error waiting for database instance to be available", err.Error())
+		return
+	}
+
+	setDatabaseInstance(&data, client, instance)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DatabaseInstanceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DatabaseInstanceResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	instance, err := client.GetDatabaseInstance(ctx, int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read database instance, got error: %s", err), err.Error())
+		return
+	}
+
+	setDatabaseInstance(&data, client, instance)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DatabaseInstanceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state DatabaseInstanceResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultDatabaseInstanceUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	instance, err := modifyDatabaseInstance(ctx, client, int(data.ID.ValueInt64()), data, state)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to update database instance, got error: %s", err), err.Error())
+		return
+	}
+
+	// Only the timeouts changed, there is nothing to read back.
+	if instance == nil {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	setDatabaseInstance(&data, client, instance)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DatabaseInstanceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data DatabaseInstanceResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	err := client.DeleteDatabaseInstance(ctx, int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to delete database instance, got error: %s", err), err.Error())
+		return
+	}
+}
+
+func (r *DatabaseInstanceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importRegional(ctx, req, resp)
+}
+
+// waitDatabaseInstanceAvailable waits for a database instance leaving the
+// pending status to be available with the changes checked by applied, if
+// any, and returns it.
+func waitDatabaseInstanceAvailable(ctx context.Context, refresh func(ctx context.Context) (*fakecloud.DatabaseInstance, error), pending string, applied func(*fakecloud.DatabaseInstance) bool) (*fakecloud.DatabaseInstance, error) {
+	status := func(instance *fakecloud.DatabaseInstance) string { return instance.Status }
+
+	instance, err := waitForChange(ctx, refresh, status, applied, []string{pending}, []string{databaseStatusAvailable})
+	if err != nil {
+		return nil, err
+	}
+
+	return instance, nil
+}
+
+// databaseInstanceClient reads and modifies database instances.
+type databaseInstanceClient interface {
+	GetDatabaseInstance(ctx context.Context, id int) (*fakecloud.DatabaseInstance, error)
+	UpdateDatabaseInstance(ctx context.Context, instance *fakecloud.DatabaseInstance) error
+}
+
+// modifyDatabaseInstance modifies database instance id from state to plan and
+// waits for the modification to be applied, returning the modified instance.
+// Nothing is sent and nil is returned when only the timeouts changed.
+func modifyDatabaseInstance(ctx context.Context, client databaseInstanceClient, id int, plan DatabaseInstanceResourceModel, state DatabaseInstanceResourceModel) (*fakecloud.DatabaseInstance, error) {
+	instance := plan.expand()
+	if *instance == *state.expand() {
+		return nil, nil
+	}
+
+	instance.ID = id
+
+	// The API applies version upgrades, resizes and storage growth in a
+	// single modification.
+	if err := client.UpdateDatabaseInstance(ctx, instance); err != nil {
+		return nil, err
+	}
+
+	modified, err := waitDatabaseInstanceAvailable(ctx, databaseInstanceRefresh(client, id), databaseStatusModifying, databaseInstanceModified(instance))
+	if err != nil {
+		return nil, fmt.Errorf("waiting for database instance to be available: %w", err)
+	}
+
+	return modified, nil
+}
+
+// databaseInstanceRefresh returns a function reading a database instance, to
+// wait on.
+func databaseInstanceRefresh(client databaseInstanceClient, id int) func(ctx context.Context) (*fakecloud.DatabaseInstance, error) {
+	return func(ctx context.Context) (*fakecloud.DatabaseInstance, error) {
+		return client.GetDatabaseInstance(ctx, id)
+	}
+}
+
+// databaseInstanceModified returns a function checking that a database
+// instance has the version, size and storage of the modification sent to the
+// API. The API may still report the instance available as it was right after
+// the modification request.
+func databaseInstanceModified(update *fakecloud.DatabaseInstance) func(*fakecloud.DatabaseInstance) bool {
+	return func(instance *fakecloud.DatabaseInstance) bool {
+		return instance.Version == update.Version &&
+			instance.Size == update.Size &&
+			instance.StorageGB == update.StorageGB
+	}
+}
+
+// expand converts the model into the database instance sent to the API. An
+// unknown backup window is sent empty for the API to choose one.
+func (m DatabaseInstanceResourceModel) expand() *fakecloud.DatabaseInstance {
+	return &fakecloud.DatabaseInstance{
+		Name:         m.Name.ValueString(),
+		Engine:       m.Engine.ValueString(),
+		Version:      m.Version.ValueString(),
+		Size:         m.Size.ValueString(),
+		StorageGB:    int(m.StorageGB.ValueInt64()),
+		BackupWindow: m.BackupWindow.ValueString(),
+	}
+}
+
+// setDatabaseInstance copies the database instance returned by the API into
+// the model. The API only returns the admin password on creation, so the
+// password already in the model is kept otherwise.
+func setDatabaseInstance(data *DatabaseInstanceResourceModel, client *apiClient, instance *fakecloud.DatabaseInstance) {
+	data.ID = types.Int64Value(int64(instance.ID))
+	data.Name = types.StringValue(instance.Name)
+	data.Region = regionValue(client.region)
+	data.Project = projectValue(client.project)
+	data.Engine = types.StringValue(instance.Engine)
+	data.Version = types.StringValue(instance.Version)
+	data.Size = types.StringValue(instance.Size)
+	data.StorageGB = types.Int64Value(int64(instance.StorageGB))
+	data.BackupWindow = types.StringValue(instance.BackupWindow)
+	data.Endpoint = types.StringValue(instance.Endpoint)
+	data.AdminUsername = types.StringValue(instance.AdminUsername)
+
+	if instance.AdminPassword != "" {
+		data.AdminPassword = types.StringValue(instance.AdminPassword)
+	}
+}
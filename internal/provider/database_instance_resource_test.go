@@ -0,0 +1,289 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+func TestStorageShrinkRequiresReplace(t *testing.T) {
+	cases := map[string]struct {
+		state    types.Int64
+		plan     types.Int64
+		expected bool
+	}{
+		"grow": {
+			state: types.Int64Value(20),
+			plan:  types.Int64Value(100),
+		},
+		"unchanged": {
+			state: types.Int64Value(20),
+			plan:  types.Int64Value(20),
+		},
+		"shrink": {
+			state:    types.Int64Value(100),
+			plan:     types.Int64Value(20),
+			expected: true,
+		},
+		"create": {
+			state: types.Int64Null(),
+			plan:  types.Int64Value(20),
+		},
+		"unknown": {
+			state: types.Int64Value(100),
+			plan:  types.Int64Unknown(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := planmodifier.Int64Request{StateValue: tc.state, PlanValue: tc.plan}
+			resp := &int64planmodifier.RequiresReplaceIfFuncResponse{}
+
+			storageShrinkRequiresReplace(context.Background(), req, resp)
+
+			if resp.RequiresReplace != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, resp.RequiresReplace)
+			}
+		})
+	}
+}
+
+func TestBackupWindowRegexp(t *testing.T) {
+	cases := map[string]bool{
+		"02:00-03:00": true,
+		"23:30-00:30": true,
+		"2:00-3:00":   false,
+		"24:00-01:00": false,
+		"02:00":       false,
+		"02:60-03:00": false,
+	}
+
+	for window, expected := range cases {
+		t.Run(window, func(t *testing.T) {
+			if got := backupWindowRegexp.MatchString(window); got != expected {
+				t.Errorf("expected %t, got %t", expected, got)
+			}
+		})
+	}
+}
+
+func TestWaitDatabaseInstanceAvailable(t *testing.T) {
+	defer func(interval time.Duration) { waitPollInterval = interval }(waitPollInterval)
+	waitPollInterval = time.Millisecond
+
+	instance := func(status string, size string) *fakecloud.DatabaseInstance {
+		return &fakecloud.DatabaseInstance{Status: status, Version: "16", Size: size, StorageGB: 20}
+	}
+
+	resized := databaseInstanceModified(&fakecloud.DatabaseInstance{Version: "16", Size: "large", StorageGB: 20})
+
+	cases := map[string]struct {
+		instances []*fakecloud.DatabaseInstance
+		pending   string
+		applied   func(*fakecloud.DatabaseInstance) bool
+		polls     int
+		err       bool
+	}{
+		"created": {
+			instances: []*fakecloud.DatabaseInstance{instance("creating", "small"), instance("available", "small")},
+			pending:   "creating",
+			polls:     2,
+		},
+		"modification not started yet": {
+			instances: []*fakecloud.DatabaseInstance{instance("available", "small"), instance("modifying", "small"), instance("available", "large")},
+			pending:   "modifying",
+			applied:   resized,
+			polls:     3,
+		},
+		"modification without modifying status": {
+			instances: []*fakecloud.DatabaseInstance{instance("available", "small"), instance("available", "large")},
+			pending:   "modifying",
+			applied:   resized,
+			polls:     2,
+		},
+		"modification never applied": {
+			instances: []*fakecloud.DatabaseInstance{instance("available", "small")},
+			pending:   "modifying",
+			applied:   resized,
+			err:       true,
+		},
+		"modification failed": {
+			instances: []*fakecloud.DatabaseInstance{instance("modifying", "small"), instance("failed", "small")},
+			pending:   "modifying",
+			applied:   resized,
+			err:       true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+
+			polls := 0
+			next := sequenceObjects(tc.instances...)
+			refresh := func(ctx context.Context) (*fakecloud.DatabaseInstance, error) {
+				polls++
+				return next(ctx)
+			}
+
+			_, err := waitDatabaseInstanceAvailable(ctx, refresh, tc.pending, tc.applied)
+			if tc.err {
+				if err == nil {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if polls != tc.polls {
+				t.Errorf("expected the wait to return after %d polls, got %d", tc.polls, polls)
+			}
+		})
+	}
+}
+
+func TestDatabaseInstanceModified(t *testing.T) {
+	update := &fakecloud.DatabaseInstance{Version: "16", Size: "large", StorageGB: 100}
+
+	cases := map[string]struct {
+		instance fakecloud.DatabaseInstance
+		expected bool
+	}{
+		"modified": {
+			instance: fakecloud.DatabaseInstance{Version: "16", Size: "large", StorageGB: 100},
+			expected: true,
+		},
+		"upgrade pending": {
+			instance: fakecloud.DatabaseInstance{Version: "15", Size: "large", StorageGB: 100},
+		},
+		"resize pending": {
+			instance: fakecloud.DatabaseInstance{Version: "16", Size: "medium", StorageGB: 100},
+		},
+		"storage growth pending": {
+			instance: fakecloud.DatabaseInstance{Version: "16", Size: "large", StorageGB: 20},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if actual := databaseInstanceModified(update)(&tc.instance); actual != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, actual)
+			}
+		})
+	}
+}
+
+// stubDatabaseInstanceClient records the modifications sent to it and
+// answers GetDatabaseInstance with instances in turn.
+type stubDatabaseInstanceClient struct {
+	instances []*fakecloud.DatabaseInstance
+	updates   []fakecloud.DatabaseInstance
+}
+
+func (c *stubDatabaseInstanceClient) GetDatabaseInstance(ctx context.Context, id int) (*fakecloud.DatabaseInstance, error) {
+	instance := c.instances[0]
+	if len(c.instances) > 1 {
+		c.instances = c.instances[1:]
+	}
+
+	return instance, nil
+}
+
+func (c *stubDatabaseInstanceClient) UpdateDatabaseInstance(ctx context.Context, instance *fakecloud.DatabaseInstance) error {
+	c.updates = append(c.updates, *instance)
+	return nil
+}
+
+func TestModifyDatabaseInstance(t *testing.T) {
+	defer func(interval time.Duration) { waitPollInterval = interval }(waitPollInterval)
+	waitPollInterval = time.Millisecond
+
+	model := func(size string, update string) DatabaseInstanceResourceModel {
+		timeoutsValue := types.ObjectNull(map[string]attr.Type{"create": types.StringType, "update": types.StringType})
+		if update != "" {
+			timeoutsValue = types.ObjectValueMust(
+				map[string]attr.Type{"create": types.StringType, "update": types.StringType},
+				map[string]attr.Value{"create": types.StringNull(), "update": types.StringValue(update)},
+			)
+		}
+
+		return DatabaseInstanceResourceModel{
+			ID:           types.Int64Value(42),
+			Name:         types.StringValue("orders"),
+			Engine:       types.StringValue("postgres"),
+			Version:      types.StringValue("16"),
+			Size:         types.StringValue(size),
+			StorageGB:    types.Int64Value(20),
+			BackupWindow: types.StringValue("02:00-03:00"),
+			Timeouts:     timeouts.Value{Object: timeoutsValue},
+		}
+	}
+
+	instance := func(status string, size string) *fakecloud.DatabaseInstance {
+		return &fakecloud.DatabaseInstance{ID: 42, Name: "orders", Engine: "postgres", Version: "16", Size: size, StorageGB: 20, BackupWindow: "02:00-03:00", Status: status}
+	}
+
+	cases := map[string]struct {
+		plan      DatabaseInstanceResourceModel
+		state     DatabaseInstanceResourceModel
+		instances []*fakecloud.DatabaseInstance
+		updates   []fakecloud.DatabaseInstance
+		expected  *fakecloud.DatabaseInstance
+	}{
+		"timeouts only": {
+			plan:  model("small", "2h"),
+			state: model("small", ""),
+		},
+		"resize": {
+			plan:  model("large", ""),
+			state: model("small", ""),
+			instances: []*fakecloud.DatabaseInstance{
+				instance("available", "small"),
+				instance("modifying", "small"),
+				instance("available", "large"),
+			},
+			updates: []fakecloud.DatabaseInstance{
+				{ID: 42, Name: "orders", Engine: "postgres", Version: "16", Size: "large", StorageGB: 20, BackupWindow: "02:00-03:00"},
+			},
+			expected: instance("available", "large"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+
+			client := &stubDatabaseInstanceClient{instances: tc.instances}
+
+			actual, err := modifyDatabaseInstance(ctx, client, 42, tc.plan, tc.state)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(tc.updates, client.updates); diff != "" {
+				t.Errorf("unexpected updates sent (-expected +actual):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("unexpected instance (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
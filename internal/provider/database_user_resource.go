@@ -0,0 +1,261 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DatabaseUserResource{}
+var _ resource.ResourceWithImportState = &DatabaseUserResource{}
+var _ resource.ResourceWithModifyPlan = &DatabaseUserResource{}
+
+func NewDatabaseUserResource() resource.Resource {
+	return &DatabaseUserResource{}
+}
+
+// DatabaseUserResource defines the resource implementation.
+type DatabaseUserResource struct {
+	providerData *providerData
+}
+
+// DatabaseUserResourceModel describes the resource data model.
+type DatabaseUserResourceModel struct {
+	ID         types.Int64  `tfsdk:"id"`
+	InstanceID types.Int64  `tfsdk:"instance_id"`
+	Name       types.String `tfsdk:"name"`
+	Password   types.String `tfsdk:"password"`
+	Region     types.String `tfsdk:"region"`
+	Project    types.String `tfsdk:"project"`
+}
+
+func (r *DatabaseUserResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_database_user"
+}
+
+func (r *DatabaseUserResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "User of a `fakecloud_database_instance`. " +
+			"Import with `<instance_id>/<id>`, prefixed with `<region>/` outside the provider region, or `<project>/<region>/` outside the provider project. " +
+			"The password is never returned by the API, it stays null after an import unless configured.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Database user identifier",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"instance_id": schema.Int64Attribute{
+				MarkdownDescription: "Identifier of the database instance. Changing it forces a new user to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the user. Changing it forces a new user to be created.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 63),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "Password of the user, 12 to 128 characters. Generated by Fakecloud when omitted. " +
+					"Changing it updates the password in place.",
+				Optional:  true,
+				Computed:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(12, 128),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Region of the database instance. Defaults to the provider region. Changing it forces a new user to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project of the database instance. Defaults to the provider project. Changing it forces a new user to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *DatabaseUserResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *DatabaseUserResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.planScope(ctx, req.Config, req.State, req.Plan, resp)
+}
+
+func (r *DatabaseUserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DatabaseUserResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	// An unknown password is sent empty for the API to generate one.
+	user, err := client.CreateDatabaseUser(ctx, &fakecloud.DatabaseUser{
+		InstanceID: int(data.InstanceID.ValueInt64()),
+		Name:       data.Name.ValueString(),
+		Password:   data.Password.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to create database user", err.Error())
+		return
+	}
+
+	setDatabaseUser(&data, client, user)
+
+	tflog.Trace(ctx, "created a database user")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DatabaseUserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DatabaseUserResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	user, err := client.GetDatabaseUser(ctx, int(data.InstanceID.ValueInt64()), int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read database user, got error: %s", err), err.Error())
+		return
+	}
+
+	setDatabaseUser(&data, client, user)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DatabaseUserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state DatabaseUserResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	// The password is the only attribute updated in place.
+	if !data.Password.Equal(state.Password) {
+		err := client.UpdateDatabaseUserPassword(ctx, int(data.InstanceID.ValueInt64()), int(data.ID.ValueInt64()), data.Password.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Unable to update database user password, got error: %s", err), err.Error())
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DatabaseUserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data DatabaseUserResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	err := client.DeleteDatabaseUser(ctx, int(data.InstanceID.ValueInt64()), int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to delete database user, got error: %s", err), err.Error())
+		return
+	}
+}
+
+func (r *DatabaseUserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importRegionalChild(ctx, req, resp, "instance_id", "id")
+}
+
+// setDatabaseUser copies the user returned by the API into the model. The API
+// only returns the password when it generated it, so the password already in
+// the model is kept otherwise.
+func setDatabaseUser(data *DatabaseUserResourceModel, client *apiClient, user *fakecloud.DatabaseUser) {
+	data.ID = types.Int64Value(int64(user.ID))
+	data.InstanceID = types.Int64Value(int64(user.InstanceID))
+	data.Name = types.StringValue(user.Name)
+	data.Region = regionValue(client.region)
+	data.Project = projectValue(client.project)
+
+	if user.Password != "" {
+		data.Password = types.StringValue(user.Password)
+	}
+}
@@ -0,0 +1,285 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// dnsRecordTypes are the record types supported by Fakecloud DNS.
+var dnsRecordTypes = []string{"A", "AAAA", "CNAME", "MX", "TXT"}
+
+// dnsLabelRegexp matches a single label of a domain name. Underscores are
+// allowed for names such as _dmarc.
+var dnsLabelRegexp = regexp.MustCompile(`^(?i)[a-z0-9_]([a-z0-9_-]{0,61}[a-z0-9_])?$`)
+
+// normalizeDomainName returns the canonical form of a domain name: lower case
+// without the trailing dot.
+func normalizeDomainName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+// validateDomainName checks that name is a valid domain name, with or
+// without its trailing dot.
+func validateDomainName(name string) error {
+	name = strings.TrimSuffix(name, ".")
+
+	if name == "" {
+		return fmt.Errorf("domain name must not be empty")
+	}
+
+	if len(name) > 253 {
+		return fmt.Errorf("domain name %q is longer than 253 characters", name)
+	}
+
+	for _, label := range strings.Split(name, ".") {
+		if !dnsLabelRegexp.MatchString(label) {
+			return fmt.Errorf("domain name %q has an invalid label %q", name, label)
+		}
+	}
+
+	return nil
+}
+
+// normalizeDNSRecordValue validates a record value of the given type and
+// returns its canonical form, the one sent to the API. Two values are the
+// same record when their canonical forms are equal.
+func normalizeDNSRecordValue(recordType string, value string) (string, error) {
+	switch recordType {
+	case "A", "AAAA":
+		addr, err := netip.ParseAddr(value)
+		if err != nil {
+			return "", fmt.Errorf("%s record value %q is not an IP address", recordType, value)
+		}
+
+		if recordType == "A" && !addr.Is4() {
+			return "", fmt.Errorf("A record value %q is not an IPv4 address", value)
+		}
+
+		if recordType == "AAAA" && (!addr.Is6() || addr.Is4In6()) {
+			return "", fmt.Errorf("AAAA record value %q is not an IPv6 address", value)
+		}
+
+		return addr.String(), nil
+	case "CNAME":
+		return normalizeDNSTarget(recordType, value)
+	case "MX":
+		fields := strings.Fields(value)
+		if len(fields) != 2 {
+			return "", fmt.Errorf("MX record value %q must have the format <priority> <host>", value)
+		}
+
+		priority, err := strconv.ParseUint(fields[0], 10, 16)
+		if err != nil {
+			return "", fmt.Errorf("MX record value %q must start with a priority between 0 and 65535", value)
+		}
+
+		host, err := normalizeDNSTarget(recordType, fields[1])
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("%d %s", priority, host), nil
+	case "TXT":
+		return unquoteTXT(value)
+	default:
+		return "", fmt.Errorf("unsupported record type %q, expected one of %s", recordType, strings.Join(dnsRecordTypes, ", "))
+	}
+}
+
+// normalizeDNSTarget returns the fully qualified, lower case form of a host
+// name a record points to.
+func normalizeDNSTarget(recordType string, host string) (string, error) {
+	if err := validateDomainName(host); err != nil {
+		return "", fmt.Errorf("%s record target: %w", recordType, err)
+	}
+
+	return normalizeDomainName(host) + ".", nil
+}
+
+// unquoteTXT returns the text of a TXT record value. The value is either the
+// bare text or, as in zone files, one or more quoted strings which are
+// concatenated.
+func unquoteTXT(value string) (string, error) {
+	trimmed := strings.TrimSpace(value)
+	if !strings.HasPrefix(trimmed, `"`) {
+		return value, nil
+	}
+
+	var text strings.Builder
+
+	for trimmed != "" {
+		if !strings.HasPrefix(trimmed, `"`) {
+			return "", fmt.Errorf("TXT record value %q mixes quoted and unquoted text", value)
+		}
+
+		end := 1
+		for ; end < len(trimmed) && trimmed[end] != '"'; end++ {
+			if trimmed[end] == '\\' {
+				end++
+			}
+		}
+
+		if end >= len(trimmed) {
+			return "", fmt.Errorf("TXT record value %q has an unterminated quoted string", value)
+		}
+
+		chunk := strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(trimmed[1:end])
+		text.WriteString(chunk)
+
+		trimmed = strings.TrimSpace(trimmed[end+1:])
+	}
+
+	return text.String(), nil
+}
+
+var (
+	_ basetypes.StringTypable                    = dnsRecordValueType{}
+	_ basetypes.StringValuableWithSemanticEquals = dnsRecordValue{}
+)
+
+// dnsRecordValueType is the type of DNS record values. Values which are the
+// same record are semantically equal, so that plans stay stable however the
+// values are written.
+type dnsRecordValueType struct {
+	basetypes.StringType
+}
+
+func (t dnsRecordValueType) String() string {
+	return "dnsRecordValueType"
+}
+
+func (t dnsRecordValueType) ValueType(ctx context.Context) attr.Value {
+	return dnsRecordValue{}
+}
+
+func (t dnsRecordValueType) Equal(o attr.Type) bool {
+	other, ok := o.(dnsRecordValueType)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t dnsRecordValueType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return dnsRecordValue{StringValue: in}, nil
+}
+
+func (t dnsRecordValueType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return dnsRecordValue{StringValue: stringValue}, nil
+}
+
+// dnsRecordValue is a DNS record value.
+type dnsRecordValue struct {
+	basetypes.StringValue
+
+	// recordType is the type of the record the value belongs to. Values read
+	// from the API carry it, those decoded from the plan or the state do not
+	// as the type is a sibling attribute.
+	recordType string
+}
+
+// newDNSRecordValue returns a value of a record of the given type.
+func newDNSRecordValue(recordType string, value string) dnsRecordValue {
+	return dnsRecordValue{StringValue: types.StringValue(value), recordType: recordType}
+}
+
+func (v dnsRecordValue) Type(ctx context.Context) attr.Type {
+	return dnsRecordValueType{}
+}
+
+func (v dnsRecordValue) Equal(o attr.Value) bool {
+	other, ok := o.(dnsRecordValue)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals reports whether both values are the same record, which
+// takes the record type of either value. Values without a record type are
+// only equal when they are written the same.
+func (v dnsRecordValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	other, ok := newValuable.(dnsRecordValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			"An unexpected value type was received while performing semantic equality checks. "+
+				"Please report this to the provider developers.\n\n"+
+				fmt.Sprintf("Expected Value Type: %T\nGot Value Type: %T", v, newValuable),
+		)
+
+		return false, diags
+	}
+
+	recordType := v.recordType
+	if recordType == "" {
+		recordType = other.recordType
+	}
+
+	if recordType == "" {
+		return v.ValueString() == other.ValueString(), diags
+	}
+
+	canonical, err := normalizeDNSRecordValue(recordType, v.ValueString())
+	if err != nil {
+		return false, diags
+	}
+
+	otherCanonical, err := normalizeDNSRecordValue(recordType, other.ValueString())
+	if err != nil {
+		return false, diags
+	}
+
+	return canonical == otherCanonical, diags
+}
+
+var _ validator.String = domainNameValidator{}
+
+// domainNameValidator validates that a string is a domain name.
+type domainNameValidator struct{}
+
+func (v domainNameValidator) Description(ctx context.Context) string {
+	return "value must be a domain name"
+}
+
+func (v domainNameValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v domainNameValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := validateDomainName(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Domain Name", err.Error())
+	}
+}
@@ -0,0 +1,368 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DNSRecordResource{}
+var _ resource.ResourceWithImportState = &DNSRecordResource{}
+var _ resource.ResourceWithModifyPlan = &DNSRecordResource{}
+var _ resource.ResourceWithValidateConfig = &DNSRecordResource{}
+
+// dnsApexName is the record name of the zone apex.
+const dnsApexName = "@"
+
+func NewDNSRecordResource() resource.Resource {
+	return &DNSRecordResource{}
+}
+
+// DNSRecordResource defines the resource implementation.
+type DNSRecordResource struct {
+	providerData *providerData
+}
+
+// DNSRecordResourceModel describes the resource data model.
+type DNSRecordResourceModel struct {
+	ID      types.Int64  `tfsdk:"id"`
+	Project types.String `tfsdk:"project"`
+	ZoneID  types.Int64  `tfsdk:"zone_id"`
+	Name    types.String `tfsdk:"name"`
+	Type    types.String `tfsdk:"type"`
+	TTL     types.Int64  `tfsdk:"ttl"`
+	Values  types.Set    `tfsdk:"values"`
+}
+
+func (r *DNSRecordResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_record"
+}
+
+func (r *DNSRecordResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Record set of a `fakecloud_dns_zone`. Values are compared in their canonical form, so e.g. host names " +
+			"with or without a trailing dot and quoted or bare TXT values do not cause changes. " +
+			"Import with `<zone_id>/<record_id>`, prefixed with `<project>/` outside the provider project.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "DNS record identifier",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project of the zone. Defaults to the provider project. Changing it forces a new record to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"zone_id": schema.Int64Attribute{
+				MarkdownDescription: "Identifier of the zone. Changing it forces a new record to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the record relative to the zone, e.g. `www`, or `@` for the zone apex. " +
+					"Changing it forces a new record to be created.",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.Any(
+						stringvalidator.OneOf(dnsApexName),
+						domainNameValidator{},
+					),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Type of the record, one of `A`, `AAAA`, `CNAME`, `MX` or `TXT`. Changing it forces a new record to be created.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(dnsRecordTypes...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ttl": schema.Int64Attribute{
+				MarkdownDescription: "Time to live of the record in seconds. Defaults to `300`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(300),
+				Validators: []validator.Int64{
+					int64validator.Between(60, 86400),
+				},
+			},
+			"values": schema.SetAttribute{
+				MarkdownDescription: "Values of the record: IPv4 addresses for `A`, IPv6 addresses for `AAAA`, a single host name for `CNAME`, " +
+					"`<priority> <host>` for `MX` and text, bare or quoted, for `TXT`.",
+				ElementType: dnsRecordValueType{},
+				Required:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *DNSRecordResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *DNSRecordResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data DNSRecordResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.Type.IsNull() || data.Type.IsUnknown() || data.Values.IsUnknown() {
+		return
+	}
+
+	var values []dnsRecordValue
+
+	resp.Diagnostics.Append(data.Values.ElementsAs(ctx, &values, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	recordType := data.Type.ValueString()
+
+	if recordType == "CNAME" && len(values) > 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("values"), "Invalid DNS Record Values", "A CNAME record must have a single value.")
+	}
+
+	seen := make(map[string]string, len(values))
+
+	for _, value := range values {
+		if value.IsUnknown() {
+			continue
+		}
+
+		canonical, err := normalizeDNSRecordValue(recordType, value.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("values"), "Invalid DNS Record Value", err.Error())
+			continue
+		}
+
+		if other, ok := seen[canonical]; ok {
+			resp.Diagnostics.AddAttributeError(path.Root("values"), "Duplicate DNS Record Value",
+				fmt.Sprintf("The values %q and %q are the same record.", other, value.ValueString()))
+		}
+
+		seen[canonical] = value.ValueString()
+	}
+}
+
+func (r *DNSRecordResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.planScope(ctx, req.Config, req.State, req.Plan, resp)
+}
+
+func (r *DNSRecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DNSRecordResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	values := canonicalDNSRecordValues(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, types.StringNull(), &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	record, err := client.CreateDNSRecord(ctx, &fakecloud.DNSRecord{
+		ZoneID: int(data.ZoneID.ValueInt64()),
+		Name:   data.Name.ValueString(),
+		Type:   data.Type.ValueString(),
+		TTL:    int(data.TTL.ValueInt64()),
+		Values: values,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to create DNS record", err.Error())
+		return
+	}
+
+	data.ID = types.Int64Value(int64(record.ID))
+
+	tflog.Trace(ctx, "created a DNS record")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DNSRecordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DNSRecordResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, types.StringNull(), &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	data.Project = projectValue(client.project)
+
+	record, err := client.GetDNSRecord(ctx, int(data.ZoneID.ValueInt64()), int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read DNS record, got error: %s", err), err.Error())
+		return
+	}
+
+	values := make([]dnsRecordValue, 0, len(record.Values))
+	for _, value := range record.Values {
+		values = append(values, newDNSRecordValue(record.Type, value))
+	}
+
+	valuesSet, diags := types.SetValueFrom(ctx, dnsRecordValueType{}, values)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ZoneID = types.Int64Value(int64(record.ZoneID))
+	data.Type = types.StringValue(record.Type)
+	data.TTL = types.Int64Value(int64(record.TTL))
+	data.Values = valuesSet
+
+	if normalizeDomainName(data.Name.ValueString()) != normalizeDomainName(record.Name) {
+		data.Name = types.StringValue(record.Name)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DNSRecordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data DNSRecordResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	values := canonicalDNSRecordValues(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, types.StringNull(), &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	err := client.UpdateDNSRecord(ctx, int(data.ZoneID.ValueInt64()), int(data.ID.ValueInt64()), int(data.TTL.ValueInt64()), values)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to update DNS record, got error: %s", err), err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DNSRecordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data DNSRecordResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, types.StringNull(), &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	err := client.DeleteDNSRecord(ctx, int(data.ZoneID.ValueInt64()), int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to delete DNS record, got error: %s", err), err.Error())
+		return
+	}
+}
+
+func (r *DNSRecordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	project, zoneID, id, err := parseGlobalChildImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_id"), zoneID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project"), projectValue(project))...)
+}
+
+// canonicalDNSRecordValues returns the canonical form of the record values,
+// the one sent to the API.
+func canonicalDNSRecordValues(ctx context.Context, data DNSRecordResourceModel, diags *diag.Diagnostics) []string {
+	var values []string
+
+	diags.Append(data.Values.ElementsAs(ctx, &values, false)...)
+
+	for i, value := range values {
+		canonical, err := normalizeDNSRecordValue(data.Type.ValueString(), value)
+		if err != nil {
+			diags.AddAttributeError(path.Root("values"), "Invalid DNS Record Value", err.Error())
+			continue
+		}
+
+		values[i] = canonical
+	}
+
+	return values
+}
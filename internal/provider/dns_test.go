@@ -0,0 +1,144 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNormalizeDNSRecordValue(t *testing.T) {
+	cases := map[string]struct {
+		recordType string
+		value      string
+		expected   string
+		err        bool
+	}{
+		"A":                       {recordType: "A", value: "192.0.2.1", expected: "192.0.2.1"},
+		"A with IPv6":             {recordType: "A", value: "2001:db8::1", err: true},
+		"A with host name":        {recordType: "A", value: "example.com", err: true},
+		"AAAA compressed":         {recordType: "AAAA", value: "2001:0DB8:0000::0001", expected: "2001:db8::1"},
+		"AAAA with IPv4":          {recordType: "AAAA", value: "192.0.2.1", err: true},
+		"AAAA with IPv4 in IPv6":  {recordType: "AAAA", value: "::ffff:192.0.2.1", err: true},
+		"CNAME without dot":       {recordType: "CNAME", value: "Target.Example.com", expected: "target.example.com."},
+		"CNAME with dot":          {recordType: "CNAME", value: "target.example.com.", expected: "target.example.com."},
+		"CNAME invalid":           {recordType: "CNAME", value: "not a host", err: true},
+		"MX":                      {recordType: "MX", value: "10  mail.example.com", expected: "10 mail.example.com."},
+		"MX leading zeros":        {recordType: "MX", value: "010 mail.example.com.", expected: "10 mail.example.com."},
+		"MX without priority":     {recordType: "MX", value: "mail.example.com", err: true},
+		"MX priority too large":   {recordType: "MX", value: "65536 mail.example.com", err: true},
+		"TXT bare":                {recordType: "TXT", value: "v=spf1 -all", expected: "v=spf1 -all"},
+		"TXT quoted":              {recordType: "TXT", value: `"v=spf1 -all"`, expected: "v=spf1 -all"},
+		"TXT split":               {recordType: "TXT", value: `"v=DKIM1; " "p=abc"`, expected: "v=DKIM1; p=abc"},
+		"TXT escaped quote":       {recordType: "TXT", value: `"say \"hi\""`, expected: `say "hi"`},
+		"TXT unterminated":        {recordType: "TXT", value: `"v=spf1`, err: true},
+		"TXT mixed":               {recordType: "TXT", value: `"a" b`, err: true},
+		"unsupported record type": {recordType: "SRV", value: "0 5 5060 sip.example.com", err: true},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			actual, err := normalizeDNSRecordValue(c.recordType, c.value)
+
+			if c.err {
+				if err == nil {
+					t.Fatalf("expected an error, got %q", actual)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if actual != c.expected {
+				t.Errorf("expected %q, got %q", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestDNSRecordValueSemanticEquals(t *testing.T) {
+	cases := map[string]struct {
+		prior    dnsRecordValue
+		value    dnsRecordValue
+		expected bool
+	}{
+		"MX spelling": {
+			prior:    dnsRecordValue{StringValue: types.StringValue("10 Mail.example.com")},
+			value:    newDNSRecordValue("MX", "10 mail.example.com."),
+			expected: true,
+		},
+		"CNAME trailing dot": {
+			prior:    dnsRecordValue{StringValue: types.StringValue("target.example.com")},
+			value:    newDNSRecordValue("CNAME", "target.example.com."),
+			expected: true,
+		},
+		"quoted TXT": {
+			prior:    dnsRecordValue{StringValue: types.StringValue(`"v=spf1 -all"`)},
+			value:    newDNSRecordValue("TXT", "v=spf1 -all"),
+			expected: true,
+		},
+		"TXT trailing dot": {
+			prior: dnsRecordValue{StringValue: types.StringValue("example.com")},
+			value: newDNSRecordValue("TXT", "example.com."),
+		},
+		"different records": {
+			prior: dnsRecordValue{StringValue: types.StringValue("192.0.2.1")},
+			value: newDNSRecordValue("A", "192.0.2.2"),
+		},
+		"invalid prior value": {
+			prior: dnsRecordValue{StringValue: types.StringValue("example.com")},
+			value: newDNSRecordValue("A", "192.0.2.1"),
+		},
+		"no record type": {
+			prior: dnsRecordValue{StringValue: types.StringValue("target.example.com")},
+			value: dnsRecordValue{StringValue: types.StringValue("target.example.com.")},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			actual, diags := c.value.StringSemanticEquals(context.Background(), c.prior)
+
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if actual != c.expected {
+				t.Errorf("expected %t, got %t", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestValidateDomainName(t *testing.T) {
+	cases := map[string]bool{
+		"example.com":        true,
+		"example.com.":       true,
+		"_dmarc.example.com": true,
+		"www":                true,
+		"":                   false,
+		"-bad.example.com":   false,
+		"bad..example.com":   false,
+		"white space.com":    false,
+	}
+
+	for name, valid := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := validateDomainName(name)
+
+			if valid && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+
+			if !valid && err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
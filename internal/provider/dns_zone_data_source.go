@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &dnsZoneDataSource{}
+	_ datasource.DataSourceWithConfigure = &dnsZoneDataSource{}
+)
+
+func NewDNSZoneDataSource() datasource.DataSource {
+	return &dnsZoneDataSource{}
+}
+
+type dnsZoneDataSource struct {
+	providerData *providerData
+}
+
+// dnsZoneDataSourceModel maps the data source schema data.
+type dnsZoneDataSourceModel struct {
+	ID          types.Int64  `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	NameServers types.List   `tfsdk:"name_servers"`
+	Project     types.String `tfsdk:"project"`
+}
+
+func (d *dnsZoneDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_zone"
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *dnsZoneDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.providerData = providerData
+}
+
+// Schema defines the schema for the data source.
+func (d *dnsZoneDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a DNS zone by identifier or domain name.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				MarkdownDescription: "Identifier of the zone. Exactly one of `id` and `name` must be set.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					int64validator.ExactlyOneOf(path.MatchRoot("name")),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Domain name of the zone, with or without the trailing dot.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					domainNameValidator{},
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the zone.",
+				Computed:            true,
+			},
+			"name_servers": schema.ListAttribute{
+				MarkdownDescription: "Name servers to delegate the domain to.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project to look up the zone in. Defaults to the provider project.",
+				Optional:            true,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *dnsZoneDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state dnsZoneDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.providerData.client(state.Project.ValueString(), "")
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Create Fakecloud API Client", err.Error())
+		return
+	}
+
+	var zone *fakecloud.DNSZone
+
+	if !state.ID.IsNull() {
+		zone, err = client.GetDNSZone(ctx, int(state.ID.ValueInt64()))
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Read Fakecloud DNS Zone", err.Error())
+			return
+		}
+	} else {
+		zones, err := client.GetDNSZones(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Read Fakecloud DNS Zones", err.Error())
+			return
+		}
+
+		name := normalizeDomainName(state.Name.ValueString())
+		for i := range zones {
+			if normalizeDomainName(zones[i].Name) == name {
+				zone = &zones[i]
+				break
+			}
+		}
+
+		if zone == nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name"), "DNS Zone Not Found",
+				fmt.Sprintf("No DNS zone named %q exists.", state.Name.ValueString()))
+			return
+		}
+	}
+
+	state.ID = types.Int64Value(int64(zone.ID))
+	state.Description = types.StringValue(zone.Description)
+
+	if normalizeDomainName(state.Name.ValueString()) != normalizeDomainName(zone.Name) {
+		state.Name = types.StringValue(zone.Name)
+	}
+
+	nameServers, diags := types.ListValueFrom(ctx, types.StringType, zone.NameServers)
+	resp.Diagnostics.Append(diags...)
+	state.NameServers = nameServers
+	state.Project = projectValue(client.project)
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
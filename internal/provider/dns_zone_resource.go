@@ -0,0 +1,244 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DNSZoneResource{}
+var _ resource.ResourceWithImportState = &DNSZoneResource{}
+var _ resource.ResourceWithModifyPlan = &DNSZoneResource{}
+
+func NewDNSZoneResource() resource.Resource {
+	return &DNSZoneResource{}
+}
+
+// DNSZoneResource defines the resource implementation.
+type DNSZoneResource struct {
+	providerData *providerData
+}
+
+// DNSZoneResourceModel describes the resource data model.
+type DNSZoneResourceModel struct {
+	ID          types.Int64  `tfsdk:"id"`
+	Project     types.String `tfsdk:"project"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	NameServers types.List   `tfsdk:"name_servers"`
+}
+
+func (r *DNSZoneResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_zone"
+}
+
+func (r *DNSZoneResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "DNS zone hosted by Fakecloud. DNS is global, zones are not bound to a region.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "DNS zone identifier",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project the DNS zone belongs to. Defaults to the provider project. Changing it forces a new DNS zone to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Domain name of the zone, e.g. `example.com`. Changing it forces a new zone to be created.",
+				Required:            true,
+				Validators: []validator.String{
+					domainNameValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the zone",
+				Optional:            true,
+			},
+			"name_servers": schema.ListAttribute{
+				MarkdownDescription: "Name servers to delegate the domain to.",
+				ElementType:         types.StringType,
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *DNSZoneResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *DNSZoneResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.planScope(ctx, req.Config, req.State, req.Plan, resp)
+}
+
+func (r *DNSZoneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DNSZoneResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, types.StringNull(), &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	zone, err := client.CreateDNSZone(ctx, &fakecloud.DNSZone{
+		Name:        normalizeDomainName(data.Name.ValueString()),
+		Description: data.Description.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to create DNS zone", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(setDNSZone(ctx, &data, zone)...)
+
+	tflog.Trace(ctx, "created a DNS zone")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DNSZoneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DNSZoneResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, types.StringNull(), &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	data.Project = projectValue(client.project)
+
+	zone, err := client.GetDNSZone(ctx, int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read DNS zone, got error: %s", err), err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(setDNSZone(ctx, &data, zone)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DNSZoneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data DNSZoneResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, types.StringNull(), &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	err := client.UpdateDNSZone(ctx, int(data.ID.ValueInt64()), data.Description.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to update DNS zone, got error: %s", err), err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DNSZoneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data DNSZoneResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, types.StringNull(), &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	err := client.DeleteDNSZone(ctx, int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to delete DNS zone, got error: %s", err), err.Error())
+		return
+	}
+}
+
+func (r *DNSZoneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importGlobal(ctx, req, resp)
+}
+
+// setDNSZone copies the zone returned by the API into the model, keeping the
+// configured spelling of the zone name.
+func setDNSZone(ctx context.Context, data *DNSZoneResourceModel, zone *fakecloud.DNSZone) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.ID = types.Int64Value(int64(zone.ID))
+	data.NameServers, diags = types.ListValueFrom(ctx, types.StringType, zone.NameServers)
+
+	if normalizeDomainName(data.Name.ValueString()) != normalizeDomainName(zone.Name) {
+		data.Name = types.StringValue(zone.Name)
+	}
+
+	// Keep description null rather than empty when not configured.
+	if zone.Description != "" || !data.Description.IsNull() {
+		data.Description = types.StringValue(zone.Description)
+	}
+
+	return diags
+}
@@ -0,0 +1,205 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &FirewallPolicyAttachmentResource{}
+var _ resource.ResourceWithImportState = &FirewallPolicyAttachmentResource{}
+var _ resource.ResourceWithModifyPlan = &FirewallPolicyAttachmentResource{}
+
+func NewFirewallPolicyAttachmentResource() resource.Resource {
+	return &FirewallPolicyAttachmentResource{}
+}
+
+// FirewallPolicyAttachmentResource defines the resource implementation.
+type FirewallPolicyAttachmentResource struct {
+	providerData *providerData
+}
+
+// FirewallPolicyAttachmentResourceModel describes the resource data model.
+type FirewallPolicyAttachmentResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	PolicyID  types.Int64  `tfsdk:"policy_id"`
+	NetworkID types.Int64  `tfsdk:"network_id"`
+	Region    types.String `tfsdk:"region"`
+	Project   types.String `tfsdk:"project"`
+}
+
+func (r *FirewallPolicyAttachmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_firewall_policy_attachment"
+}
+
+func (r *FirewallPolicyAttachmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Applies a `fakecloud_firewall_policy` to a network, filtering the traffic of every VM of the network. " +
+			"Import with `<policy_id>/<network_id>`, prefixed with `<region>/` outside the provider region, or `<project>/<region>/` outside the provider project.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Attachment identifier, `<policy_id>/<network_id>`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"policy_id": schema.Int64Attribute{
+				MarkdownDescription: "Identifier of the firewall policy. Changing it forces a new attachment to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"network_id": schema.Int64Attribute{
+				MarkdownDescription: "Identifier of the network. Changing it forces a new attachment to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Region of the firewall policy and network. Defaults to the provider region. Changing it forces a new attachment to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project of the firewall policy and network. Defaults to the provider project. Changing it forces a new attachment to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *FirewallPolicyAttachmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *FirewallPolicyAttachmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.planScope(ctx, req.Config, req.State, req.Plan, resp)
+}
+
+func (r *FirewallPolicyAttachmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data FirewallPolicyAttachmentResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	policyID, networkID := int(data.PolicyID.ValueInt64()), int(data.NetworkID.ValueInt64())
+
+	err := client.AttachFirewallPolicy(ctx, policyID, networkID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to attach firewall policy", err.Error())
+		return
+	}
+
+	tflog.Trace(ctx, "attached a firewall policy")
+
+	data.ID = types.StringValue(fmt.Sprintf("%d/%d", policyID, networkID))
+	data.Region = regionValue(client.region)
+	data.Project = projectValue(client.project)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FirewallPolicyAttachmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data FirewallPolicyAttachmentResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	policyID, networkID := int(data.PolicyID.ValueInt64()), int(data.NetworkID.ValueInt64())
+
+	_, err := client.GetFirewallPolicyAttachment(ctx, policyID, networkID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read firewall policy attachment, got error: %s", err), err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%d/%d", policyID, networkID))
+	data.Region = regionValue(client.region)
+	data.Project = projectValue(client.project)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FirewallPolicyAttachmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	updateReplaceOnly(ctx, req, resp)
+}
+
+func (r *FirewallPolicyAttachmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data FirewallPolicyAttachmentResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	err := client.DetachFirewallPolicy(ctx, int(data.PolicyID.ValueInt64()), int(data.NetworkID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to detach firewall policy, got error: %s", err), err.Error())
+		return
+	}
+}
+
+func (r *FirewallPolicyAttachmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importRegionalChild(ctx, req, resp, "policy_id", "network_id")
+}
@@ -0,0 +1,524 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &FirewallPolicyResource{}
+var _ resource.ResourceWithImportState = &FirewallPolicyResource{}
+var _ resource.ResourceWithModifyPlan = &FirewallPolicyResource{}
+var _ resource.ResourceWithValidateConfig = &FirewallPolicyResource{}
+
+// Values of the firewall rule attributes.
+const (
+	firewallActionAllow = "allow"
+	firewallActionDeny  = "deny"
+
+	firewallDirectionIngress = "ingress"
+	firewallDirectionEgress  = "egress"
+
+	firewallProtocolTCP  = "tcp"
+	firewallProtocolUDP  = "udp"
+	firewallProtocolICMP = "icmp"
+	firewallProtocolAll  = "all"
+)
+
+func NewFirewallPolicyResource() resource.Resource {
+	return &FirewallPolicyResource{}
+}
+
+// FirewallPolicyResource defines the resource implementation.
+type FirewallPolicyResource struct {
+	providerData *providerData
+}
+
+// FirewallPolicyResourceModel describes the resource data model.
+type FirewallPolicyResourceModel struct {
+	ID          types.Int64         `tfsdk:"id"`
+	Name        types.String        `tfsdk:"name"`
+	Description types.String        `tfsdk:"description"`
+	Region      types.String        `tfsdk:"region"`
+	Project     types.String        `tfsdk:"project"`
+	Rules       []FirewallRuleModel `tfsdk:"rule"`
+}
+
+// FirewallRuleModel describes a rule block.
+type FirewallRuleModel struct {
+	Priority    types.Int64  `tfsdk:"priority"`
+	Action      types.String `tfsdk:"action"`
+	Direction   types.String `tfsdk:"direction"`
+	Protocol    types.String `tfsdk:"protocol"`
+	Ports       types.String `tfsdk:"ports"`
+	CIDRBlocks  types.Set    `tfsdk:"cidr_blocks"`
+	Description types.String `tfsdk:"description"`
+}
+
+func (r *FirewallPolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_firewall_policy"
+}
+
+func (r *FirewallPolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Firewall policy filtering the traffic of the networks it is attached to with `fakecloud_firewall_policy_attachment`. " +
+			"Rules are evaluated by ascending priority, the first matching rule applies. Changing, adding, removing or reordering rules " +
+			"updates the policy in place, replacing all its rules at once. " +
+			"Import with `<id>`, prefixed with `<region>/` outside the provider region, or `<project>/<region>/` outside the provider project.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Firewall policy identifier",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the firewall policy",
+				Required:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the firewall policy",
+				Optional:            true,
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Region the firewall policy is created in. Defaults to the provider region. Changing it forces a new firewall policy to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project the firewall policy belongs to. Defaults to the provider project. Changing it forces a new firewall policy to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"rule": schema.ListNestedBlock{
+				MarkdownDescription: "Rule of the policy. Traffic matching no rule is denied. At most 200 rules.",
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(200),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"priority": schema.Int64Attribute{
+							MarkdownDescription: "Priority of the rule between 1 and 65535, unique within the policy. Lower priorities are evaluated first.",
+							Required:            true,
+							Validators: []validator.Int64{
+								int64validator.Between(1, 65535),
+							},
+						},
+						"action": schema.StringAttribute{
+							MarkdownDescription: "Action applied to the matching traffic, `allow` or `deny`.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(firewallActionAllow, firewallActionDeny),
+							},
+						},
+						"direction": schema.StringAttribute{
+							MarkdownDescription: "Direction of the matching traffic, `ingress` or `egress`.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(firewallDirectionIngress, firewallDirectionEgress),
+							},
+						},
+						"protocol": schema.StringAttribute{
+							MarkdownDescription: "Protocol of the matching traffic, `tcp`, `udp`, `icmp` or `all`.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(firewallProtocolTCP, firewallProtocolUDP, firewallProtocolICMP, firewallProtocolAll),
+							},
+						},
+						"ports": schema.StringAttribute{
+							MarkdownDescription: "Port, e.g. `443`, or range of ports, e.g. `8000-8080`, of the matching traffic. " +
+								"Only for `tcp` and `udp`, all ports match when omitted.",
+							Optional: true,
+						},
+						"cidr_blocks": schema.SetAttribute{
+							MarkdownDescription: "Networks, in CIDR notation, the matching traffic comes from for `ingress` rules or goes to for `egress` rules.",
+							ElementType:         types.StringType,
+							Required:            true,
+							Validators: []validator.Set{
+								setvalidator.SizeAtLeast(1),
+							},
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "Description of the rule",
+							Optional:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *FirewallPolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *FirewallPolicyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data FirewallPolicyResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	priorities := map[int64]int{}
+
+	for i, rule := range data.Rules {
+		rulePath := path.Root("rule").AtListIndex(i)
+
+		if !rule.Priority.IsUnknown() {
+			priority := rule.Priority.ValueInt64()
+
+			if other, ok := priorities[priority]; ok {
+				resp.Diagnostics.AddAttributeError(rulePath.AtName("priority"), "Duplicate Rule Priority",
+					fmt.Sprintf("Priority %d is already used by rule %d, rules are evaluated in priority order and must not share one.", priority, other))
+			} else {
+				priorities[priority] = i
+			}
+		}
+
+		if !rule.Ports.IsNull() && !rule.Ports.IsUnknown() {
+			if _, _, err := parsePortRange(rule.Ports.ValueString()); err != nil {
+				resp.Diagnostics.AddAttributeError(rulePath.AtName("ports"), "Invalid Ports", err.Error())
+			}
+
+			if protocol := rule.Protocol.ValueString(); !rule.Protocol.IsUnknown() && protocol != firewallProtocolTCP && protocol != firewallProtocolUDP {
+				resp.Diagnostics.AddAttributeError(rulePath.AtName("ports"), "Invalid Ports",
+					fmt.Sprintf("Ports only apply to tcp and udp rules, this rule matches %s traffic.", protocol))
+			}
+		}
+
+		if rule.CIDRBlocks.IsUnknown() {
+			continue
+		}
+
+		var blocks []types.String
+		resp.Diagnostics.Append(rule.CIDRBlocks.ElementsAs(ctx, &blocks, false)...)
+
+		for _, block := range blocks {
+			if block.IsUnknown() {
+				continue
+			}
+
+			if _, err := netip.ParsePrefix(block.ValueString()); err != nil {
+				resp.Diagnostics.AddAttributeError(rulePath.AtName("cidr_blocks"), "Invalid CIDR Block",
+					fmt.Sprintf("%q is not a network in CIDR notation: %s", block.ValueString(), err))
+			}
+		}
+	}
+}
+
+func (r *FirewallPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.planScope(ctx, req.Config, req.State, req.Plan, resp)
+}
+
+func (r *FirewallPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data FirewallPolicyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	policy, diags := expandFirewallPolicy(ctx, data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy, err := client.CreateFirewallPolicy(ctx, policy)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to create firewall policy", err.Error())
+		return
+	}
+
+	tflog.Trace(ctx, "created a firewall policy")
+
+	resp.Diagnostics.Append(setFirewallPolicy(ctx, &data, client, policy)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FirewallPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data FirewallPolicyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	policy, err := client.GetFirewallPolicy(ctx, int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read firewall policy, got error: %s", err), err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(setFirewallPolicy(ctx, &data, client, policy)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FirewallPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data FirewallPolicyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	policy, diags := expandFirewallPolicy(ctx, data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The API swaps the rules atomically, so the attached networks never see
+	// a partial rule set while rules are reordered.
+	err := client.UpdateFirewallPolicy(ctx, policy)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to update firewall policy, got error: %s", err), err.Error())
+		return
+	}
+
+	policy, err = client.GetFirewallPolicy(ctx, policy.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to read firewall policy, got error: %s", err), err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(setFirewallPolicy(ctx, &data, client, policy)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FirewallPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data FirewallPolicyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	// The API refuses to delete a policy still attached to networks.
+	err := client.DeleteFirewallPolicy(ctx, int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to delete firewall policy, got error: %s", err), err.Error())
+		return
+	}
+}
+
+func (r *FirewallPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importRegional(ctx, req, resp)
+}
+
+// parsePortRange parses a port, e.g. 443, or an inclusive range of ports,
+// e.g. 8000-8080.
+func parsePortRange(ports string) (int, int, error) {
+	from, to, isRange := strings.Cut(ports, "-")
+
+	first, err := strconv.Atoi(from)
+	if err != nil || first < 1 || first > 65535 {
+		return 0, 0, fmt.Errorf("invalid ports %q: expected a port between 1 and 65535, or a range such as 8000-8080", ports)
+	}
+
+	if !isRange {
+		return first, first, nil
+	}
+
+	last, err := strconv.Atoi(to)
+	if err != nil || last < first || last > 65535 {
+		return 0, 0, fmt.Errorf("invalid ports %q: expected a range of ports between 1 and 65535, from the lowest to the highest", ports)
+	}
+
+	return first, last, nil
+}
+
+// orderFirewallRules orders the rules returned by the API, sorted by
+// priority, like the prior rules with the same priority, so that the order of
+// the rule blocks in the configuration does not cause a diff. Rules without a
+// prior rule follow in priority order.
+func orderFirewallRules(prior []FirewallRuleModel, rules []fakecloud.FirewallRule) []fakecloud.FirewallRule {
+	position := make(map[int]int, len(prior))
+
+	for i, rule := range prior {
+		if !rule.Priority.IsUnknown() && !rule.Priority.IsNull() {
+			position[int(rule.Priority.ValueInt64())] = i
+		}
+	}
+
+	ordered := make([]fakecloud.FirewallRule, len(rules))
+	copy(ordered, rules)
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		pi, iok := position[ordered[i].Priority]
+		pj, jok := position[ordered[j].Priority]
+
+		switch {
+		case iok && jok:
+			return pi < pj
+		case iok != jok:
+			return iok
+		default:
+			return ordered[i].Priority < ordered[j].Priority
+		}
+	})
+
+	return ordered
+}
+
+// expandFirewallPolicy converts the model into the policy sent to the API.
+func expandFirewallPolicy(ctx context.Context, data FirewallPolicyResourceModel) (*fakecloud.FirewallPolicy, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	policy := &fakecloud.FirewallPolicy{
+		ID:          int(data.ID.ValueInt64()),
+		Name:        data.Name.ValueString(),
+		Description: data.Description.ValueString(),
+		Rules:       make([]fakecloud.FirewallRule, 0, len(data.Rules)),
+	}
+
+	for _, rule := range data.Rules {
+		expanded := fakecloud.FirewallRule{
+			Priority:    int(rule.Priority.ValueInt64()),
+			Action:      rule.Action.ValueString(),
+			Direction:   rule.Direction.ValueString(),
+			Protocol:    rule.Protocol.ValueString(),
+			Ports:       rule.Ports.ValueString(),
+			Description: rule.Description.ValueString(),
+		}
+
+		diags.Append(rule.CIDRBlocks.ElementsAs(ctx, &expanded.CIDRBlocks, false)...)
+
+		policy.Rules = append(policy.Rules, expanded)
+	}
+
+	return policy, diags
+}
+
+// setFirewallPolicy copies the policy returned by the API into the model,
+// keeping the rules in the prior order. Optional strings stay null when empty
+// and not configured.
+func setFirewallPolicy(ctx context.Context, data *FirewallPolicyResourceModel, client *apiClient, policy *fakecloud.FirewallPolicy) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.ID = types.Int64Value(int64(policy.ID))
+	data.Name = types.StringValue(policy.Name)
+	data.Region = regionValue(client.region)
+	data.Project = projectValue(client.project)
+
+	if policy.Description != "" || !data.Description.IsNull() {
+		data.Description = types.StringValue(policy.Description)
+	}
+
+	prior := make(map[int]FirewallRuleModel, len(data.Rules))
+
+	for _, rule := range data.Rules {
+		prior[int(rule.Priority.ValueInt64())] = rule
+	}
+
+	rules := make([]FirewallRuleModel, 0, len(policy.Rules))
+
+	for _, rule := range orderFirewallRules(data.Rules, policy.Rules) {
+		blocks, d := types.SetValueFrom(ctx, types.StringType, rule.CIDRBlocks)
+		diags.Append(d...)
+
+		model := FirewallRuleModel{
+			Priority:    types.Int64Value(int64(rule.Priority)),
+			Action:      types.StringValue(rule.Action),
+			Direction:   types.StringValue(rule.Direction),
+			Protocol:    types.StringValue(rule.Protocol),
+			Ports:       types.StringNull(),
+			CIDRBlocks:  blocks,
+			Description: types.StringNull(),
+		}
+
+		if rule.Ports != "" {
+			model.Ports = types.StringValue(rule.Ports)
+		}
+
+		if configured, ok := prior[rule.Priority]; rule.Description != "" || (ok && !configured.Description.IsNull()) {
+			model.Description = types.StringValue(rule.Description)
+		}
+
+		rules = append(rules, model)
+	}
+
+	data.Rules = rules
+
+	return diags
+}
@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+func TestParsePortRange(t *testing.T) {
+	cases := map[string]struct {
+		ports string
+		from  int
+		to    int
+		err   bool
+	}{
+		"port": {
+			ports: "443",
+			from:  443,
+			to:    443,
+		},
+		"range": {
+			ports: "8000-8080",
+			from:  8000,
+			to:    8080,
+		},
+		"zero": {
+			ports: "0",
+			err:   true,
+		},
+		"too high": {
+			ports: "1-65536",
+			err:   true,
+		},
+		"reversed range": {
+			ports: "8080-8000",
+			err:   true,
+		},
+		"not a number": {
+			ports: "https",
+			err:   true,
+		},
+		"open range": {
+			ports: "8000-",
+			err:   true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			from, to, err := parsePortRange(tc.ports)
+			if tc.err {
+				if err == nil {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if from != tc.from || to != tc.to {
+				t.Errorf("expected %d-%d, got %d-%d", tc.from, tc.to, from, to)
+			}
+		})
+	}
+}
+
+func TestOrderFirewallRules(t *testing.T) {
+	rules := []fakecloud.FirewallRule{{Priority: 10}, {Priority: 20}, {Priority: 30}, {Priority: 40}}
+
+	cases := map[string]struct {
+		prior    []int64
+		expected []int
+	}{
+		"no prior rules": {
+			expected: []int{10, 20, 30, 40},
+		},
+		"prior order": {
+			prior:    []int64{30, 10, 40, 20},
+			expected: []int{30, 10, 40, 20},
+		},
+		"new rules last": {
+			prior:    []int64{40, 20},
+			expected: []int{40, 20, 10, 30},
+		},
+		"removed rules": {
+			prior:    []int64{50, 20, 10},
+			expected: []int{20, 10, 30, 40},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			prior := make([]FirewallRuleModel, 0, len(tc.prior))
+			for _, priority := range tc.prior {
+				prior = append(prior, FirewallRuleModel{Priority: types.Int64Value(priority)})
+			}
+
+			var got []int
+			for _, rule := range orderFirewallRules(prior, rules) {
+				got = append(got, rule.Priority)
+			}
+
+			if diff := cmp.Diff(tc.expected, got); diff != "" {
+				t.Errorf("unexpected order (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
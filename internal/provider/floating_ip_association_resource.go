@@ -0,0 +1,246 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &FloatingIPAssociationResource{}
+var _ resource.ResourceWithImportState = &FloatingIPAssociationResource{}
+var _ resource.ResourceWithModifyPlan = &FloatingIPAssociationResource{}
+
+func NewFloatingIPAssociationResource() resource.Resource {
+	return &FloatingIPAssociationResource{}
+}
+
+// FloatingIPAssociationResource defines the resource implementation.
+type FloatingIPAssociationResource struct {
+	providerData *providerData
+}
+
+// FloatingIPAssociationResourceModel describes the resource data model.
+type FloatingIPAssociationResourceModel struct {
+	ID           types.Int64  `tfsdk:"id"`
+	FloatingIPID types.Int64  `tfsdk:"floating_ip_id"`
+	VMID         types.Int64  `tfsdk:"vm_id"`
+	Region       types.String `tfsdk:"region"`
+	Project      types.String `tfsdk:"project"`
+}
+
+func (r *FloatingIPAssociationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_floating_ip_association"
+}
+
+func (r *FloatingIPAssociationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Associates a `fakecloud_floating_ip` with a virtual machine. A replaced VM gets the same address back once " +
+			"the association is recreated. Import with the floating IP identifier.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Association identifier, the same as `floating_ip_id`",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"floating_ip_id": schema.Int64Attribute{
+				MarkdownDescription: "Identifier of the floating IP. Changing it forces a new association to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"vm_id": schema.Int64Attribute{
+				MarkdownDescription: "Identifier of the VM. Changing it forces a new association to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Region of the floating IP and VM. Defaults to the provider region. Changing it forces a new association to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project of the floating IP and VM. Defaults to the provider project. Changing it forces a new association to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *FloatingIPAssociationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *FloatingIPAssociationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.planScope(ctx, req.Config, req.State, req.Plan, resp)
+}
+
+func (r *FloatingIPAssociationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data FloatingIPAssociationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if err := associateFloatingIP(ctx, client, &data); err != nil {
+		resp.Diagnostics.AddError("Unable to associate floating IP", err.Error())
+		return
+	}
+
+	data.Region = regionValue(client.region)
+	data.Project = projectValue(client.project)
+
+	tflog.Trace(ctx, "associated a floating IP")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FloatingIPAssociationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data FloatingIPAssociationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	associated, err := readFloatingIPAssociation(ctx, client, &data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read floating IP, got error: %s", err), err.Error())
+		return
+	}
+
+	if !associated {
+		tflog.Warn(ctx, "Floating IP is no longer associated, removing the association from state", map[string]interface{}{"id": data.ID.ValueInt64()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Region = regionValue(client.region)
+	data.Project = projectValue(client.project)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FloatingIPAssociationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	updateReplaceOnly(ctx, req, resp)
+}
+
+func (r *FloatingIPAssociationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data FloatingIPAssociationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	if err := disassociateFloatingIP(ctx, client, data); err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to disassociate floating IP, got error: %s", err), err.Error())
+		return
+	}
+}
+
+func (r *FloatingIPAssociationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importRegional(ctx, req, resp)
+}
+
+// floatingIPAssociationClient associates floating IPs with VMs.
+type floatingIPAssociationClient interface {
+	AssignFloatingIP(ctx context.Context, id int, vmID int) error
+	GetFloatingIP(ctx context.Context, id int) (*fakecloud.FloatingIP, error)
+	UnassignFloatingIP(ctx context.Context, id int) error
+}
+
+// associateFloatingIP assigns the floating IP of data to its VM. The
+// association is identified by the floating IP.
+func associateFloatingIP(ctx context.Context, client floatingIPAssociationClient, data *FloatingIPAssociationResourceModel) error {
+	if err := client.AssignFloatingIP(ctx, int(data.FloatingIPID.ValueInt64()), int(data.VMID.ValueInt64())); err != nil {
+		return err
+	}
+
+	data.ID = data.FloatingIPID
+
+	return nil
+}
+
+// readFloatingIPAssociation refreshes data from its floating IP, reporting
+// false when the floating IP was detached outside of Terraform.
+func readFloatingIPAssociation(ctx context.Context, client floatingIPAssociationClient, data *FloatingIPAssociationResourceModel) (bool, error) {
+	ip, err := client.GetFloatingIP(ctx, int(data.ID.ValueInt64()))
+	if err != nil {
+		return false, err
+	}
+
+	if ip.VMID == 0 {
+		return false, nil
+	}
+
+	data.FloatingIPID = types.Int64Value(int64(ip.ID))
+	data.VMID = types.Int64Value(int64(ip.VMID))
+
+	return true, nil
+}
+
+// disassociateFloatingIP unassigns the floating IP of data from its VM.
+func disassociateFloatingIP(ctx context.Context, client floatingIPAssociationClient, data FloatingIPAssociationResourceModel) error {
+	return client.UnassignFloatingIP(ctx, int(data.FloatingIPID.ValueInt64()))
+}
@@ -0,0 +1,173 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+// stubFloatingIPAssociationClient answers GetFloatingIP with ip and records
+// the floating IPs assigned and unassigned.
+type stubFloatingIPAssociationClient struct {
+	ip  *fakecloud.FloatingIP
+	err error
+
+	assigned   map[int]int
+	unassigned []int
+}
+
+func (c *stubFloatingIPAssociationClient) AssignFloatingIP(ctx context.Context, id int, vmID int) error {
+	if c.err != nil {
+		return c.err
+	}
+
+	if c.assigned == nil {
+		c.assigned = map[int]int{}
+	}
+
+	c.assigned[id] = vmID
+
+	return nil
+}
+
+func (c *stubFloatingIPAssociationClient) GetFloatingIP(ctx context.Context, id int) (*fakecloud.FloatingIP, error) {
+	return c.ip, c.err
+}
+
+func (c *stubFloatingIPAssociationClient) UnassignFloatingIP(ctx context.Context, id int) error {
+	if c.err != nil {
+		return c.err
+	}
+
+	c.unassigned = append(c.unassigned, id)
+
+	return nil
+}
+
+func TestAssociateFloatingIP(t *testing.T) {
+	cases := map[string]struct {
+		err      error
+		assigned map[int]int
+		expected FloatingIPAssociationResourceModel
+	}{
+		"associated": {
+			assigned: map[int]int{42: 7},
+			expected: FloatingIPAssociationResourceModel{
+				ID:           types.Int64Value(42),
+				FloatingIPID: types.Int64Value(42),
+				VMID:         types.Int64Value(7),
+			},
+		},
+		"rejected": {
+			err: errors.New("unexpected status code: 409"),
+			expected: FloatingIPAssociationResourceModel{
+				FloatingIPID: types.Int64Value(42),
+				VMID:         types.Int64Value(7),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := &stubFloatingIPAssociationClient{err: tc.err}
+			data := FloatingIPAssociationResourceModel{
+				FloatingIPID: types.Int64Value(42),
+				VMID:         types.Int64Value(7),
+			}
+
+			err := associateFloatingIP(context.Background(), client, &data)
+			if (err != nil) != (tc.err != nil) {
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+
+			if diff := cmp.Diff(tc.assigned, client.assigned); diff != "" {
+				t.Errorf("unexpected assignments (-expected +actual):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.expected, data); diff != "" {
+				t.Errorf("unexpected model (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReadFloatingIPAssociation(t *testing.T) {
+	cases := map[string]struct {
+		ip         *fakecloud.FloatingIP
+		associated bool
+		expected   FloatingIPAssociationResourceModel
+	}{
+		"associated": {
+			ip:         &fakecloud.FloatingIP{ID: 42, Address: "203.0.113.10", VMID: 7},
+			associated: true,
+			expected: FloatingIPAssociationResourceModel{
+				ID:           types.Int64Value(42),
+				FloatingIPID: types.Int64Value(42),
+				VMID:         types.Int64Value(7),
+			},
+		},
+		"moved to another VM": {
+			ip:         &fakecloud.FloatingIP{ID: 42, Address: "203.0.113.10", VMID: 8},
+			associated: true,
+			expected: FloatingIPAssociationResourceModel{
+				ID:           types.Int64Value(42),
+				FloatingIPID: types.Int64Value(42),
+				VMID:         types.Int64Value(8),
+			},
+		},
+		"detached": {
+			ip: &fakecloud.FloatingIP{ID: 42, Address: "203.0.113.10"},
+			expected: FloatingIPAssociationResourceModel{
+				ID: types.Int64Value(42),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// An imported association only has its identifier.
+			data := FloatingIPAssociationResourceModel{ID: types.Int64Value(42)}
+
+			associated, err := readFloatingIPAssociation(context.Background(), &stubFloatingIPAssociationClient{ip: tc.ip}, &data)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if associated != tc.associated {
+				t.Errorf("expected associated %t, got %t", tc.associated, associated)
+			}
+
+			if diff := cmp.Diff(tc.expected, data); diff != "" {
+				t.Errorf("unexpected model (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDisassociateFloatingIP(t *testing.T) {
+	client := &stubFloatingIPAssociationClient{}
+	data := FloatingIPAssociationResourceModel{
+		ID:           types.Int64Value(42),
+		FloatingIPID: types.Int64Value(42),
+		VMID:         types.Int64Value(7),
+	}
+
+	if err := disassociateFloatingIP(context.Background(), client, data); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff([]int{42}, client.unassigned); diff != "" {
+		t.Errorf("unexpected unassignments (-expected +actual):\n%s", diff)
+	}
+}
+
+func TestFloatingIPAssociationResourceImportState(t *testing.T) {
+	testRegionalImportState(t, &FloatingIPAssociationResource{})
+}
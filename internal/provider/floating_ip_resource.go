@@ -0,0 +1,249 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &FloatingIPResource{}
+var _ resource.ResourceWithImportState = &FloatingIPResource{}
+var _ resource.ResourceWithModifyPlan = &FloatingIPResource{}
+
+func NewFloatingIPResource() resource.Resource {
+	return &FloatingIPResource{}
+}
+
+// FloatingIPResource defines the resource implementation.
+type FloatingIPResource struct {
+	providerData *providerData
+}
+
+// FloatingIPResourceModel describes the resource data model.
+type FloatingIPResourceModel struct {
+	ID          types.Int64  `tfsdk:"id"`
+	Address     types.String `tfsdk:"address"`
+	Description types.String `tfsdk:"description"`
+	Region      types.String `tfsdk:"region"`
+	Project     types.String `tfsdk:"project"`
+	VMID        types.Int64  `tfsdk:"vm_id"`
+}
+
+func (r *FloatingIPResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_floating_ip"
+}
+
+func (r *FloatingIPResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Public IP address reserved independently of any VM, so it survives VM replacement. " +
+			"Attach it to a VM with `fakecloud_floating_ip_association`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Floating IP identifier",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"address": schema.StringAttribute{
+				MarkdownDescription: "Public IP address",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the floating IP",
+				Optional:            true,
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Region the address is reserved in. Defaults to the provider region. Changing it forces a new floating IP to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project the floating IP belongs to. Defaults to the provider project. Changing it forces a new floating IP to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"vm_id": schema.Int64Attribute{
+				MarkdownDescription: "Identifier of the VM the floating IP is associated with, null when unassociated.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *FloatingIPResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *FloatingIPResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.planScope(ctx, req.Config, req.State, req.Plan, resp)
+}
+
+func (r *FloatingIPResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data FloatingIPResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	ip, err := client.CreateFloatingIP(ctx, &fakecloud.FloatingIP{
+		Description: data.Description.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to create floating IP", err.Error())
+		return
+	}
+
+	r.setFloatingIP(&data, client, ip)
+
+	tflog.Trace(ctx, "created a floating IP")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FloatingIPResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data FloatingIPResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	ip, err := client.GetFloatingIP(ctx, int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read floating IP, got error: %s", err), err.Error())
+		return
+	}
+
+	r.setFloatingIP(&data, client, ip)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FloatingIPResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data FloatingIPResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	id := int(data.ID.ValueInt64())
+
+	err := client.UpdateFloatingIP(ctx, id, data.Description.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to update floating IP, got error: %s", err), err.Error())
+		return
+	}
+
+	ip, err := client.GetFloatingIP(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to read floating IP, got error: %s", err), err.Error())
+		return
+	}
+
+	r.setFloatingIP(&data, client, ip)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FloatingIPResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data FloatingIPResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	err := client.DeleteFloatingIP(ctx, int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to delete floating IP, got error: %s", err), err.Error())
+		return
+	}
+}
+
+func (r *FloatingIPResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importRegional(ctx, req, resp)
+}
+
+// setFloatingIP copies the floating IP returned by the API into the model.
+func (r *FloatingIPResource) setFloatingIP(data *FloatingIPResourceModel, client *apiClient, ip *fakecloud.FloatingIP) {
+	data.ID = types.Int64Value(int64(ip.ID))
+	data.Address = types.StringValue(ip.Address)
+	data.Region = regionValue(client.region)
+	data.Project = projectValue(client.project)
+
+	// Keep description null rather than empty when not configured.
+	if ip.Description != "" || !data.Description.IsNull() {
+		data.Description = types.StringValue(ip.Description)
+	}
+
+	data.VMID = types.Int64Null()
+	if ip.VMID != 0 {
+		data.VMID = types.Int64Value(int64(ip.VMID))
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+func TestFloatingIPResourceSetFloatingIP(t *testing.T) {
+	cases := map[string]struct {
+		description types.String
+		ip          fakecloud.FloatingIP
+		expected    FloatingIPResourceModel
+	}{
+		"unassigned": {
+			description: types.StringNull(),
+			ip:          fakecloud.FloatingIP{ID: 42, Address: "203.0.113.10"},
+			expected: FloatingIPResourceModel{
+				ID:          types.Int64Value(42),
+				Address:     types.StringValue("203.0.113.10"),
+				Description: types.StringNull(),
+				Region:      types.StringValue("eu-central"),
+				Project:     types.StringNull(),
+				VMID:        types.Int64Null(),
+			},
+		},
+		"assigned": {
+			description: types.StringValue("web"),
+			ip:          fakecloud.FloatingIP{ID: 42, Address: "203.0.113.10", Description: "web", VMID: 7},
+			expected: FloatingIPResourceModel{
+				ID:          types.Int64Value(42),
+				Address:     types.StringValue("203.0.113.10"),
+				Description: types.StringValue("web"),
+				Region:      types.StringValue("eu-central"),
+				Project:     types.StringNull(),
+				VMID:        types.Int64Value(7),
+			},
+		},
+		"description emptied": {
+			description: types.StringValue(""),
+			ip:          fakecloud.FloatingIP{ID: 42, Address: "203.0.113.10"},
+			expected: FloatingIPResourceModel{
+				ID:          types.Int64Value(42),
+				Address:     types.StringValue("203.0.113.10"),
+				Description: types.StringValue(""),
+				Region:      types.StringValue("eu-central"),
+				Project:     types.StringNull(),
+				VMID:        types.Int64Null(),
+			},
+		},
+		"description set outside of Terraform": {
+			description: types.StringNull(),
+			ip:          fakecloud.FloatingIP{ID: 42, Address: "203.0.113.10", Description: "manual"},
+			expected: FloatingIPResourceModel{
+				ID:          types.Int64Value(42),
+				Address:     types.StringValue("203.0.113.10"),
+				Description: types.StringValue("manual"),
+				Region:      types.StringValue("eu-central"),
+				Project:     types.StringNull(),
+				VMID:        types.Int64Null(),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &FloatingIPResource{}
+			data := FloatingIPResourceModel{Description: tc.description}
+
+			r.setFloatingIP(&data, &apiClient{region: "eu-central"}, &tc.ip)
+
+			if diff := cmp.Diff(tc.expected, data); diff != "" {
+				t.Errorf("unexpected model (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFloatingIPResourceImportState(t *testing.T) {
+	testRegionalImportState(t, &FloatingIPResource{})
+}
@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// iamPolicyVersion is the current version of the policy language.
+const iamPolicyVersion = "2024-01-01"
+
+// Effects of a policy statement.
+const (
+	iamEffectAllow = "Allow"
+	iamEffectDeny  = "Deny"
+)
+
+// Types of the principals a policy is attached to.
+const (
+	iamPrincipalUser = "user"
+	iamPrincipalRole = "role"
+)
+
+// iamNameRegexp matches the names of IAM users, roles and policies.
+var iamNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_.@+=,-]{1,64}$`)
+
+// iamPolicyDocument is a policy, granting or denying actions on resources.
+// Roles use the same document, with principals, as their trust policy.
+type iamPolicyDocument struct {
+	Version   string               `json:"Version"`
+	Statement []iamPolicyStatement `json:"Statement"`
+}
+
+// iamPolicyStatement is a single statement of a policy document.
+type iamPolicyStatement struct {
+	Sid       string                              `json:"Sid,omitempty"`
+	Effect    string                              `json:"Effect"`
+	Principal map[string]iamStringList            `json:"Principal,omitempty"`
+	Action    iamStringList                       `json:"Action"`
+	Resource  iamStringList                       `json:"Resource,omitempty"`
+	Condition map[string]map[string]iamStringList `json:"Condition,omitempty"`
+}
+
+// iamStringList is a list of strings in a policy document, which may also be
+// written as a single string.
+type iamStringList []string
+
+func (l *iamStringList) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err == nil {
+		*l = iamStringList{value}
+		return nil
+	}
+
+	var values []string
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("expected a string or a list of strings, got %s", data)
+	}
+
+	*l = values
+
+	return nil
+}
+
+// parseIAMPolicyDocument parses and validates a policy document.
+func parseIAMPolicyDocument(document string) (*iamPolicyDocument, error) {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(document)))
+	decoder.DisallowUnknownFields()
+
+	var doc iamPolicyDocument
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid policy document: %w", err)
+	}
+
+	if doc.Version != iamPolicyVersion {
+		return nil, fmt.Errorf("invalid policy document: unsupported Version %q, expected %q", doc.Version, iamPolicyVersion)
+	}
+
+	if len(doc.Statement) == 0 {
+		return nil, fmt.Errorf("invalid policy document: at least one Statement is required")
+	}
+
+	for i, statement := range doc.Statement {
+		if statement.Effect != iamEffectAllow && statement.Effect != iamEffectDeny {
+			return nil, fmt.Errorf("invalid policy document: statement %d: Effect must be %q or %q, got %q", i, iamEffectAllow, iamEffectDeny, statement.Effect)
+		}
+
+		if len(statement.Action) == 0 {
+			return nil, fmt.Errorf("invalid policy document: statement %d: at least one Action is required", i)
+		}
+	}
+
+	return &doc, nil
+}
+
+var _ validator.String = iamPolicyDocumentValidator{}
+
+// iamPolicyDocumentValidator validates that a string is a policy document.
+type iamPolicyDocumentValidator struct{}
+
+func (v iamPolicyDocumentValidator) Description(ctx context.Context) string {
+	return "value must be a policy document"
+}
+
+func (v iamPolicyDocumentValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v iamPolicyDocumentValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := parseIAMPolicyDocument(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Policy Document", err.Error())
+	}
+}
@@ -0,0 +1,253 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &IAMPolicyAttachmentResource{}
+var _ resource.ResourceWithImportState = &IAMPolicyAttachmentResource{}
+var _ resource.ResourceWithModifyPlan = &IAMPolicyAttachmentResource{}
+
+func NewIAMPolicyAttachmentResource() resource.Resource {
+	return &IAMPolicyAttachmentResource{}
+}
+
+// IAMPolicyAttachmentResource defines the resource implementation.
+type IAMPolicyAttachmentResource struct {
+	providerData *providerData
+}
+
+// IAMPolicyAttachmentResourceModel describes the resource data model.
+type IAMPolicyAttachmentResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	Project  types.String `tfsdk:"project"`
+	PolicyID types.Int64  `tfsdk:"policy_id"`
+	UserID   types.Int64  `tfsdk:"user_id"`
+	RoleID   types.Int64  `tfsdk:"role_id"`
+}
+
+func (r *IAMPolicyAttachmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_policy_attachment"
+}
+
+func (r *IAMPolicyAttachmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Attaches a `fakecloud_iam_policy` to an IAM user or role. " +
+			"Import with `<policy_id>/user/<user_id>` or `<policy_id>/role/<role_id>`, prefixed with `<project>/` outside the provider project.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Attachment identifier, `<policy_id>/user/<user_id>` or `<policy_id>/role/<role_id>`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project of the policy and principal. Defaults to the provider project. Changing it forces a new attachment to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"policy_id": schema.Int64Attribute{
+				MarkdownDescription: "Identifier of the policy. Changing it forces a new attachment to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"user_id": schema.Int64Attribute{
+				MarkdownDescription: "Identifier of the user to attach the policy to. Exactly one of `user_id` and `role_id` must be set. " +
+					"Changing it forces a new attachment to be created.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.ExactlyOneOf(path.MatchRoot("role_id")),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"role_id": schema.Int64Attribute{
+				MarkdownDescription: "Identifier of the role to attach the policy to. Changing it forces a new attachment to be created.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *IAMPolicyAttachmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *IAMPolicyAttachmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.planScope(ctx, req.Config, req.State, req.Plan, resp)
+}
+
+func (r *IAMPolicyAttachmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data IAMPolicyAttachmentResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, types.StringNull(), &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	attachment := expandIAMPolicyAttachment(data)
+
+	err := client.AttachIAMPolicy(ctx, attachment)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to attach IAM policy", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(iamPolicyAttachmentID(attachment))
+
+	tflog.Trace(ctx, "attached an IAM policy")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IAMPolicyAttachmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data IAMPolicyAttachmentResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, types.StringNull(), &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	data.Project = projectValue(client.project)
+
+	attachments, err := client.GetIAMPolicyAttachments(ctx, int(data.PolicyID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read IAM policy attachments, got error: %s", err), err.Error())
+		return
+	}
+
+	attachment := expandIAMPolicyAttachment(data)
+
+	for _, a := range attachments {
+		if a == *attachment {
+			data.ID = types.StringValue(iamPolicyAttachmentID(attachment))
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+			return
+		}
+	}
+
+	// The policy was detached outside of Terraform.
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *IAMPolicyAttachmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	updateReplaceOnly(ctx, req, resp)
+}
+
+func (r *IAMPolicyAttachmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data IAMPolicyAttachmentResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, types.StringNull(), &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	err := client.DetachIAMPolicy(ctx, expandIAMPolicyAttachment(data))
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to detach IAM policy, got error: %s", err), err.Error())
+		return
+	}
+}
+
+func (r *IAMPolicyAttachmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	project, policyID, principalType, principalID, err := parseIAMPolicyAttachmentID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+
+	principalPath := path.Root("user_id")
+	if principalType == iamPrincipalRole {
+		principalPath = path.Root("role_id")
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("policy_id"), policyID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, principalPath, principalID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project"), projectValue(project))...)
+}
+
+// expandIAMPolicyAttachment converts the model into the attachment sent to
+// the API.
+func expandIAMPolicyAttachment(data IAMPolicyAttachmentResourceModel) *fakecloud.IAMPolicyAttachment {
+	attachment := &fakecloud.IAMPolicyAttachment{
+		PolicyID:      int(data.PolicyID.ValueInt64()),
+		PrincipalType: iamPrincipalUser,
+		PrincipalID:   int(data.UserID.ValueInt64()),
+	}
+
+	if !data.RoleID.IsNull() {
+		attachment.PrincipalType = iamPrincipalRole
+		attachment.PrincipalID = int(data.RoleID.ValueInt64())
+	}
+
+	return attachment
+}
+
+// iamPolicyAttachmentID returns the Terraform identifier of an attachment.
+func iamPolicyAttachmentID(attachment *fakecloud.IAMPolicyAttachment) string {
+	return fmt.Sprintf("%d/%s/%d", attachment.PolicyID, attachment.PrincipalType, attachment.PrincipalID)
+}
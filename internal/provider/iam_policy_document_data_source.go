@@ -0,0 +1,244 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource = &iamPolicyDocumentDataSource{}
+)
+
+func NewIAMPolicyDocumentDataSource() datasource.DataSource {
+	return &iamPolicyDocumentDataSource{}
+}
+
+// iamPolicyDocumentDataSource builds policy documents locally, it does not
+// call the API.
+type iamPolicyDocumentDataSource struct{}
+
+// iamPolicyDocumentDataSourceModel maps the data source schema data.
+type iamPolicyDocumentDataSourceModel struct {
+	Version    types.String              `tfsdk:"version"`
+	Statements []iamPolicyStatementModel `tfsdk:"statement"`
+	JSON       types.String              `tfsdk:"json"`
+}
+
+// iamPolicyStatementModel describes a statement block.
+type iamPolicyStatementModel struct {
+	Sid        types.String              `tfsdk:"sid"`
+	Effect     types.String              `tfsdk:"effect"`
+	Actions    []string                  `tfsdk:"actions"`
+	Resources  []string                  `tfsdk:"resources"`
+	Principals []iamPolicyPrincipalModel `tfsdk:"principals"`
+	Conditions []iamPolicyConditionModel `tfsdk:"condition"`
+}
+
+// iamPolicyPrincipalModel describes a principals block.
+type iamPolicyPrincipalModel struct {
+	Type        types.String `tfsdk:"type"`
+	Identifiers []string     `tfsdk:"identifiers"`
+}
+
+// iamPolicyConditionModel describes a condition block.
+type iamPolicyConditionModel struct {
+	Test     types.String `tfsdk:"test"`
+	Variable types.String `tfsdk:"variable"`
+	Values   []string     `tfsdk:"values"`
+}
+
+func (d *iamPolicyDocumentDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_policy_document"
+}
+
+// Schema defines the schema for the data source.
+func (d *iamPolicyDocumentDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Builds an IAM policy document in HCL, for the `policy` of a `fakecloud_iam_policy` " +
+			"or the `assume_role_policy` of a `fakecloud_iam_role`. The document is built locally, without calling the API.",
+
+		Attributes: map[string]schema.Attribute{
+			"version": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Version of the policy language. Defaults to `%s`, the only supported version.", iamPolicyVersion),
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(iamPolicyVersion),
+				},
+			},
+			"json": schema.StringAttribute{
+				MarkdownDescription: "The policy document as JSON.",
+				Computed:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"statement": schema.ListNestedBlock{
+				MarkdownDescription: "Statement of the policy. At least one is required.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"sid": schema.StringAttribute{
+							MarkdownDescription: "Identifier of the statement.",
+							Optional:            true,
+						},
+						"effect": schema.StringAttribute{
+							MarkdownDescription: "Whether the statement allows or denies the actions, `Allow` or `Deny`. Defaults to `Allow`.",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(iamEffectAllow, iamEffectDeny),
+							},
+						},
+						"actions": schema.SetAttribute{
+							MarkdownDescription: "Actions the statement applies to, e.g. `compute:GetVM` or `storage:*`.",
+							ElementType:         types.StringType,
+							Required:            true,
+							Validators: []validator.Set{
+								setvalidator.SizeAtLeast(1),
+							},
+						},
+						"resources": schema.SetAttribute{
+							MarkdownDescription: "Resources the statement applies to. Omitted in trust policies.",
+							ElementType:         types.StringType,
+							Optional:            true,
+						},
+					},
+					Blocks: map[string]schema.Block{
+						"principals": schema.ListNestedBlock{
+							MarkdownDescription: "Principals the statement applies to, in trust policies.",
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"type": schema.StringAttribute{
+										MarkdownDescription: "Type of the principals, e.g. `Service` or `User`.",
+										Required:            true,
+									},
+									"identifiers": schema.SetAttribute{
+										MarkdownDescription: "Identifiers of the principals, e.g. `compute.fakecloud`.",
+										ElementType:         types.StringType,
+										Required:            true,
+									},
+								},
+							},
+						},
+						"condition": schema.ListNestedBlock{
+							MarkdownDescription: "Condition restricting when the statement applies.",
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"test": schema.StringAttribute{
+										MarkdownDescription: "Condition operator, e.g. `StringEquals` or `IpAddress`.",
+										Required:            true,
+									},
+									"variable": schema.StringAttribute{
+										MarkdownDescription: "Context key the operator is applied to, e.g. `fakecloud:SourceIp`.",
+										Required:            true,
+									},
+									"values": schema.SetAttribute{
+										MarkdownDescription: "Values the context key is compared with. The condition holds when any of them matches.",
+										ElementType:         types.StringType,
+										Required:            true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *iamPolicyDocumentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state iamPolicyDocumentDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	document, err := json.MarshalIndent(buildIAMPolicyDocument(state), "", "  ")
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Build Policy Document", err.Error())
+		return
+	}
+
+	state.JSON = types.StringValue(string(document))
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// buildIAMPolicyDocument converts the data source configuration into a policy
+// document. Sets are sorted so the document does not change between runs.
+func buildIAMPolicyDocument(data iamPolicyDocumentDataSourceModel) iamPolicyDocument {
+	doc := iamPolicyDocument{
+		Version:   iamPolicyVersion,
+		Statement: make([]iamPolicyStatement, 0, len(data.Statements)),
+	}
+
+	if !data.Version.IsNull() {
+		doc.Version = data.Version.ValueString()
+	}
+
+	for _, s := range data.Statements {
+		statement := iamPolicyStatement{
+			Sid:      s.Sid.ValueString(),
+			Effect:   iamEffectAllow,
+			Action:   sortedStrings(s.Actions),
+			Resource: sortedStrings(s.Resources),
+		}
+
+		if !s.Effect.IsNull() {
+			statement.Effect = s.Effect.ValueString()
+		}
+
+		for _, p := range s.Principals {
+			if statement.Principal == nil {
+				statement.Principal = map[string]iamStringList{}
+			}
+
+			statement.Principal[p.Type.ValueString()] = sortedStrings(append(statement.Principal[p.Type.ValueString()], p.Identifiers...))
+		}
+
+		for _, c := range s.Conditions {
+			if statement.Condition == nil {
+				statement.Condition = map[string]map[string]iamStringList{}
+			}
+
+			test, variable := c.Test.ValueString(), c.Variable.ValueString()
+			if statement.Condition[test] == nil {
+				statement.Condition[test] = map[string]iamStringList{}
+			}
+
+			statement.Condition[test][variable] = sortedStrings(append(statement.Condition[test][variable], c.Values...))
+		}
+
+		doc.Statement = append(doc.Statement, statement)
+	}
+
+	return doc
+}
+
+// sortedStrings returns a sorted copy of values, nil when empty.
+func sortedStrings(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+
+	return slices.Compact(sorted)
+}
@@ -0,0 +1,242 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &IAMPolicyResource{}
+var _ resource.ResourceWithImportState = &IAMPolicyResource{}
+var _ resource.ResourceWithModifyPlan = &IAMPolicyResource{}
+
+func NewIAMPolicyResource() resource.Resource {
+	return &IAMPolicyResource{}
+}
+
+// IAMPolicyResource defines the resource implementation.
+type IAMPolicyResource struct {
+	providerData *providerData
+}
+
+// IAMPolicyResourceModel describes the resource data model.
+type IAMPolicyResourceModel struct {
+	ID          types.Int64          `tfsdk:"id"`
+	Project     types.String         `tfsdk:"project"`
+	Name        types.String         `tfsdk:"name"`
+	Description types.String         `tfsdk:"description"`
+	Policy      jsontypes.Normalized `tfsdk:"policy"`
+}
+
+func (r *IAMPolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_policy"
+}
+
+func (r *IAMPolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "IAM policy granting or denying actions on resources, attached to users and roles with " +
+			"`fakecloud_iam_policy_attachment`. IAM is global, policies are not bound to a region.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "IAM policy identifier",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project the IAM policy belongs to. Defaults to the provider project. Changing it forces a new IAM policy to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the policy, up to 64 letters, digits and `_.@+=,-`. Changing it forces a new policy to be created.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(iamNameRegexp, "must be up to 64 letters, digits and _.@+=,- characters"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the policy",
+				Optional:            true,
+			},
+			"policy": schema.StringAttribute{
+				MarkdownDescription: "Policy document as JSON. " +
+					"Typically built with the `fakecloud_iam_policy_document` data source. " +
+					"Formatting and key order are ignored when comparing with the current policy.",
+				CustomType: jsontypes.NormalizedType{},
+				Required:   true,
+				Validators: []validator.String{
+					iamPolicyDocumentValidator{},
+				},
+			},
+		},
+	}
+}
+
+func (r *IAMPolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *IAMPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.planScope(ctx, req.Config, req.State, req.Plan, resp)
+}
+
+func (r *IAMPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data IAMPolicyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, types.StringNull(), &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	policy, err := client.CreateIAMPolicy(ctx, &fakecloud.IAMPolicy{
+		Name:        data.Name.ValueString(),
+		Description: data.Description.ValueString(),
+		Document:    data.Policy.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to create IAM policy", err.Error())
+		return
+	}
+
+	data.ID = types.Int64Value(int64(policy.ID))
+
+	tflog.Trace(ctx, "created an IAM policy")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IAMPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data IAMPolicyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, types.StringNull(), &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	data.Project = projectValue(client.project)
+
+	policy, err := client.GetIAMPolicy(ctx, int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read IAM policy, got error: %s", err), err.Error())
+		return
+	}
+
+	data.Name = types.StringValue(policy.Name)
+
+	// The semantic equality of the JSON type keeps the prior spelling of the
+	// policy when only its formatting differs.
+	data.Policy = jsontypes.NewNormalizedValue(policy.Document)
+
+	// Keep description null rather than empty when not configured.
+	if policy.Description != "" || !data.Description.IsNull() {
+		data.Description = types.StringValue(policy.Description)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IAMPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data IAMPolicyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, types.StringNull(), &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	err := client.UpdateIAMPolicy(ctx, &fakecloud.IAMPolicy{
+		ID:          int(data.ID.ValueInt64()),
+		Name:        data.Name.ValueString(),
+		Description: data.Description.ValueString(),
+		Document:    data.Policy.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to update IAM policy, got error: %s", err), err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IAMPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data IAMPolicyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, types.StringNull(), &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	err := client.DeleteIAMPolicy(ctx, int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to delete IAM policy, got error: %s", err), err.Error())
+		return
+	}
+}
+
+func (r *IAMPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importGlobal(ctx, req, resp)
+}
@@ -0,0 +1,242 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &IAMRoleResource{}
+var _ resource.ResourceWithImportState = &IAMRoleResource{}
+var _ resource.ResourceWithModifyPlan = &IAMRoleResource{}
+
+func NewIAMRoleResource() resource.Resource {
+	return &IAMRoleResource{}
+}
+
+// IAMRoleResource defines the resource implementation.
+type IAMRoleResource struct {
+	providerData *providerData
+}
+
+// IAMRoleResourceModel describes the resource data model.
+type IAMRoleResourceModel struct {
+	ID               types.Int64          `tfsdk:"id"`
+	Project          types.String         `tfsdk:"project"`
+	Name             types.String         `tfsdk:"name"`
+	Description      types.String         `tfsdk:"description"`
+	AssumeRolePolicy jsontypes.Normalized `tfsdk:"assume_role_policy"`
+}
+
+func (r *IAMRoleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_role"
+}
+
+func (r *IAMRoleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "IAM role, a set of permissions assumed by the principals its trust policy allows, e.g. VMs or users. " +
+			"IAM is global, roles are not bound to a region.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "IAM role identifier",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project the IAM role belongs to. Defaults to the provider project. Changing it forces a new IAM role to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the role, up to 64 letters, digits and `_.@+=,-`. Changing it forces a new role to be created.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(iamNameRegexp, "must be up to 64 letters, digits and _.@+=,- characters"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the role",
+				Optional:            true,
+			},
+			"assume_role_policy": schema.StringAttribute{
+				MarkdownDescription: "Trust policy of the role as JSON, listing the principals allowed to assume it. " +
+					"Typically built with the `fakecloud_iam_policy_document` data source. " +
+					"Formatting and key order are ignored when comparing with the current policy.",
+				CustomType: jsontypes.NormalizedType{},
+				Required:   true,
+				Validators: []validator.String{
+					iamPolicyDocumentValidator{},
+				},
+			},
+		},
+	}
+}
+
+func (r *IAMRoleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *IAMRoleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.planScope(ctx, req.Config, req.State, req.Plan, resp)
+}
+
+func (r *IAMRoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data IAMRoleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, types.StringNull(), &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	role, err := client.CreateIAMRole(ctx, &fakecloud.IAMRole{
+		Name:             data.Name.ValueString(),
+		Description:      data.Description.ValueString(),
+		AssumeRolePolicy: data.AssumeRolePolicy.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to create IAM role", err.Error())
+		return
+	}
+
+	data.ID = types.Int64Value(int64(role.ID))
+
+	tflog.Trace(ctx, "created an IAM role")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IAMRoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data IAMRoleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, types.StringNull(), &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	data.Project = projectValue(client.project)
+
+	role, err := client.GetIAMRole(ctx, int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read IAM role, got error: %s", err), err.Error())
+		return
+	}
+
+	data.Name = types.StringValue(role.Name)
+
+	// The semantic equality of the JSON type keeps the prior spelling of the
+	// policy when only its formatting differs.
+	data.AssumeRolePolicy = jsontypes.NewNormalizedValue(role.AssumeRolePolicy)
+
+	// Keep description null rather than empty when not configured.
+	if role.Description != "" || !data.Description.IsNull() {
+		data.Description = types.StringValue(role.Description)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IAMRoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data IAMRoleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, types.StringNull(), &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	err := client.UpdateIAMRole(ctx, &fakecloud.IAMRole{
+		ID:               int(data.ID.ValueInt64()),
+		Name:             data.Name.ValueString(),
+		Description:      data.Description.ValueString(),
+		AssumeRolePolicy: data.AssumeRolePolicy.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to update IAM role, got error: %s", err), err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IAMRoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data IAMRoleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, types.StringNull(), &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	err := client.DeleteIAMRole(ctx, int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to delete IAM role, got error: %s", err), err.Error())
+		return
+	}
+}
+
+func (r *IAMRoleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importGlobal(ctx, req, resp)
+}
@@ -0,0 +1,112 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseIAMPolicyDocument(t *testing.T) {
+	cases := map[string]struct {
+		document string
+		err      bool
+	}{
+		"valid": {
+			document: `{"Version": "2024-01-01", "Statement": [{"Effect": "Allow", "Action": ["storage:GetObject"], "Resource": ["bucket/artifacts/*"]}]}`,
+		},
+		"single action string": {
+			document: `{"Version": "2024-01-01", "Statement": [{"Effect": "Deny", "Action": "storage:*"}]}`,
+		},
+		"trust policy": {
+			document: `{"Version": "2024-01-01", "Statement": [{"Effect": "Allow", "Action": "iam:AssumeRole", "Principal": {"Service": "compute.fakecloud"}}]}`,
+		},
+		"not JSON": {
+			document: `Version: 2024-01-01`,
+			err:      true,
+		},
+		"unknown version": {
+			document: `{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "storage:*"}]}`,
+			err:      true,
+		},
+		"no statement": {
+			document: `{"Version": "2024-01-01", "Statement": []}`,
+			err:      true,
+		},
+		"invalid effect": {
+			document: `{"Version": "2024-01-01", "Statement": [{"Effect": "allow", "Action": "storage:*"}]}`,
+			err:      true,
+		},
+		"no action": {
+			document: `{"Version": "2024-01-01", "Statement": [{"Effect": "Allow", "Resource": "*"}]}`,
+			err:      true,
+		},
+		"unknown key": {
+			document: `{"Version": "2024-01-01", "Statement": [{"Effect": "Allow", "Action": "storage:*", "Actions": "storage:*"}]}`,
+			err:      true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseIAMPolicyDocument(c.document)
+
+			if c.err && err == nil {
+				t.Fatal("expected an error")
+			}
+
+			if !c.err && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestBuildIAMPolicyDocument(t *testing.T) {
+	data := iamPolicyDocumentDataSourceModel{
+		Version: types.StringNull(),
+		Statements: []iamPolicyStatementModel{
+			{
+				Sid:       types.StringValue("ReadArtifacts"),
+				Effect:    types.StringNull(),
+				Actions:   []string{"storage:ListObjects", "storage:GetObject"},
+				Resources: []string{"bucket/artifacts/*", "bucket/artifacts"},
+				Conditions: []iamPolicyConditionModel{
+					{Test: types.StringValue("IpAddress"), Variable: types.StringValue("fakecloud:SourceIp"), Values: []string{"10.0.0.0/8"}},
+					{Test: types.StringValue("IpAddress"), Variable: types.StringValue("fakecloud:SourceIp"), Values: []string{"192.168.0.0/16", "10.0.0.0/8"}},
+				},
+			},
+			{
+				Sid:     types.StringNull(),
+				Effect:  types.StringValue("Allow"),
+				Actions: []string{"iam:AssumeRole"},
+				Principals: []iamPolicyPrincipalModel{
+					{Type: types.StringValue("Service"), Identifiers: []string{"compute.fakecloud"}},
+				},
+			},
+		},
+	}
+
+	document, err := json.Marshal(buildIAMPolicyDocument(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := `{"Version":"2024-01-01","Statement":[` +
+		`{"Sid":"ReadArtifacts","Effect":"Allow","Action":["storage:GetObject","storage:ListObjects"],"Resource":["bucket/artifacts","bucket/artifacts/*"],` +
+		`"Condition":{"IpAddress":{"fakecloud:SourceIp":["10.0.0.0/8","192.168.0.0/16"]}}},` +
+		`{"Effect":"Allow","Principal":{"Service":["compute.fakecloud"]},"Action":["iam:AssumeRole"]}]}`
+
+	if diff := cmp.Diff(expected, string(document)); diff != "" {
+		t.Errorf("unexpected document (-expected +actual):\n%s", diff)
+	}
+
+	// The built document must pass the validation of the resources using it.
+	if _, err := parseIAMPolicyDocument(string(document)); err != nil {
+		t.Errorf("built document is invalid: %s", err)
+	}
+}
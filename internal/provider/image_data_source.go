@@ -0,0 +1,260 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &imageDataSource{}
+	_ datasource.DataSourceWithConfigure = &imageDataSource{}
+)
+
+func NewImageDataSource() datasource.DataSource {
+	return &imageDataSource{}
+}
+
+type imageDataSource struct {
+	providerData *providerData
+}
+
+// imageDataSourceModel maps the data source schema data.
+type imageDataSourceModel struct {
+	ID           types.Int64  `tfsdk:"id"`
+	NameRegex    types.String `tfsdk:"name_regex"`
+	Owner        types.String `tfsdk:"owner"`
+	Tags         types.Map    `tfsdk:"tags"`
+	MostRecent   types.Bool   `tfsdk:"most_recent"`
+	Region       types.String `tfsdk:"region"`
+	Project      types.String `tfsdk:"project"`
+	Name         types.String `tfsdk:"name"`
+	ImageOwner   types.String `tfsdk:"image_owner"`
+	ImageTags    types.Map    `tfsdk:"image_tags"`
+	Architecture types.String `tfsdk:"architecture"`
+	CreatedAt    types.String `tfsdk:"created_at"`
+}
+
+// imageFilter selects images by name, owner and tags.
+type imageFilter struct {
+	nameRegex *regexp.Regexp
+	owner     string
+	tags      map[string]string
+}
+
+func (d *imageDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_image"
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *imageDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.providerData = providerData
+}
+
+// Schema defines the schema for the data source.
+func (d *imageDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Finds a VM image by name, owner and tags. The lookup must match exactly one image unless `most_recent` is set.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				MarkdownDescription: "Image identifier, to be used as the `image_id` of a `fakecloud_virtual_machine`.",
+				Computed:            true,
+			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Regular expression, in [RE2 syntax](https://github.com/google/re2/wiki/Syntax), the image name must match.",
+				Optional:            true,
+			},
+			"owner": schema.StringAttribute{
+				MarkdownDescription: "Owner the image must have, e.g. `fakecloud` for public images.",
+				Optional:            true,
+			},
+			"tags": schema.MapAttribute{
+				MarkdownDescription: "Tags the image must have. The image may have other tags, see `image_tags`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"most_recent": schema.BoolAttribute{
+				MarkdownDescription: "Whether to pick the most recently created image when several match, instead of failing.",
+				Optional:            true,
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Region to look up the image in. Defaults to the provider region.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project to look up the image in. Defaults to the provider project.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the image.",
+				Computed:            true,
+			},
+			"image_owner": schema.StringAttribute{
+				MarkdownDescription: "Owner of the image.",
+				Computed:            true,
+			},
+			"image_tags": schema.MapAttribute{
+				MarkdownDescription: "All the tags of the image.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"architecture": schema.StringAttribute{
+				MarkdownDescription: "CPU architecture the image is built for, e.g. `x86_64`.",
+				Computed:            true,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "Creation time of the image, in RFC 3339 format.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *imageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state imageDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := imageFilter{
+		owner: state.Owner.ValueString(),
+	}
+
+	if !state.NameRegex.IsNull() {
+		nameRegex, err := regexp.Compile(state.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid Name Regex", err.Error())
+			return
+		}
+
+		filter.nameRegex = nameRegex
+	}
+
+	resp.Diagnostics.Append(state.Tags.ElementsAs(ctx, &filter.tags, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.providerData.client(state.Project.ValueString(), state.Region.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("region"), "Unable to Create Fakecloud API Client", err.Error())
+		return
+	}
+
+	images, err := client.GetImages(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Fakecloud Images",
+			err.Error(),
+		)
+		return
+	}
+
+	image, err := selectImage(images, filter, state.MostRecent.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Find Fakecloud Image", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(setImage(ctx, &state, image)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Region = regionValue(client.region)
+	state.Project = projectValue(client.project)
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// setImage copies the image found into the model. The owner and tags filters
+// are kept as configured, the image ones are set apart.
+func setImage(ctx context.Context, state *imageDataSourceModel, image fakecloud.Image) diag.Diagnostics {
+	tags, diags := types.MapValueFrom(ctx, types.StringType, image.Tags)
+
+	state.ID = types.Int64Value(int64(image.ID))
+	state.Name = types.StringValue(image.Name)
+	state.ImageOwner = types.StringValue(image.Owner)
+	state.ImageTags = tags
+	state.Architecture = types.StringValue(image.Architecture)
+	state.CreatedAt = types.StringValue(image.CreatedAt.Format(time.RFC3339))
+
+	return diags
+}
+
+// matches reports whether the image passes the filter.
+func (f imageFilter) matches(image fakecloud.Image) bool {
+	if f.nameRegex != nil && !f.nameRegex.MatchString(image.Name) {
+		return false
+	}
+
+	if f.owner != "" && image.Owner != f.owner {
+		return false
+	}
+
+	for key, value := range f.tags {
+		if tag, ok := image.Tags[key]; !ok || tag != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// selectImage returns the single image passing the filter, or the most
+// recently created one when mostRecent is set.
+func selectImage(images []fakecloud.Image, filter imageFilter, mostRecent bool) (fakecloud.Image, error) {
+	var matches []fakecloud.Image
+
+	for _, image := range images {
+		if filter.matches(image) {
+			matches = append(matches, image)
+		}
+	}
+
+	switch {
+	case len(matches) == 0:
+		return fakecloud.Image{}, fmt.Errorf("no image matches the given criteria")
+	case len(matches) > 1 && !mostRecent:
+		return fakecloud.Image{}, fmt.Errorf("%d images match the given criteria, narrow the search or set most_recent to true", len(matches))
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].CreatedAt.After(matches[j].CreatedAt) })
+
+	return matches[0], nil
+}
@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+func TestSelectImage(t *testing.T) {
+	images := []fakecloud.Image{
+		{ID: 1, Name: "ubuntu-22.04-20240101", Owner: "fakecloud", Tags: map[string]string{"os": "ubuntu"}, CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: 2, Name: "ubuntu-22.04-20240301", Owner: "fakecloud", Tags: map[string]string{"os": "ubuntu"}, CreatedAt: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: 3, Name: "ubuntu-22.04-golden", Owner: "platform", Tags: map[string]string{"os": "ubuntu", "golden": "true"}, CreatedAt: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: 4, Name: "debian-12-20240201", Owner: "fakecloud", Tags: map[string]string{"os": "debian"}, CreatedAt: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	cases := map[string]struct {
+		filter     imageFilter
+		mostRecent bool
+		expected   int
+		err        bool
+	}{
+		"single match": {
+			filter:   imageFilter{nameRegex: regexp.MustCompile(`^debian-`)},
+			expected: 4,
+		},
+		"several matches": {
+			filter: imageFilter{nameRegex: regexp.MustCompile(`^ubuntu-`)},
+			err:    true,
+		},
+		"most recent": {
+			filter:     imageFilter{nameRegex: regexp.MustCompile(`^ubuntu-`), owner: "fakecloud"},
+			mostRecent: true,
+			expected:   2,
+		},
+		"tags": {
+			filter:   imageFilter{tags: map[string]string{"os": "ubuntu", "golden": "true"}},
+			expected: 3,
+		},
+		"no match": {
+			filter:     imageFilter{owner: "nobody"},
+			mostRecent: true,
+			err:        true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := selectImage(images, tc.filter, tc.mostRecent)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected error, got image %d", got.ID)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got.ID != tc.expected {
+				t.Errorf("expected image %d, got %d", tc.expected, got.ID)
+			}
+		})
+	}
+}
+
+func TestSetImage(t *testing.T) {
+	image := fakecloud.Image{
+		ID:           3,
+		Name:         "ubuntu-22.04-golden",
+		Owner:        "platform",
+		Tags:         map[string]string{"os": "ubuntu", "golden": "true"},
+		Architecture: "x86_64",
+		CreatedAt:    time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	state := imageDataSourceModel{
+		Owner: types.StringNull(),
+		Tags:  types.MapValueMust(types.StringType, map[string]attr.Value{"golden": types.StringValue("true")}),
+	}
+
+	if diags := setImage(context.Background(), &state, image); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	expected := imageDataSourceModel{
+		ID:         types.Int64Value(3),
+		Owner:      types.StringNull(),
+		Tags:       types.MapValueMust(types.StringType, map[string]attr.Value{"golden": types.StringValue("true")}),
+		Name:       types.StringValue("ubuntu-22.04-golden"),
+		ImageOwner: types.StringValue("platform"),
+		ImageTags: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"os":     types.StringValue("ubuntu"),
+			"golden": types.StringValue("true"),
+		}),
+		Architecture: types.StringValue("x86_64"),
+		CreatedAt:    types.StringValue("2024-02-01T00:00:00Z"),
+	}
+
+	if diff := cmp.Diff(expected, state); diff != "" {
+		t.Errorf("unexpected model (-expected +actual):\n%s", diff)
+	}
+}
@@ -0,0 +1,347 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ImageFromSnapshotResource{}
+var _ resource.ResourceWithImportState = &ImageFromSnapshotResource{}
+var _ resource.ResourceWithModifyPlan = &ImageFromSnapshotResource{}
+
+// Statuses reported by the Fakecloud API for an image.
+const (
+	imageStatusPending   = "pending"
+	imageStatusAvailable = "available"
+)
+
+// defaultImageCreateTimeout bounds the creation of an image when the
+// configuration does not set timeouts.create.
+const defaultImageCreateTimeout = 30 * time.Minute
+
+func NewImageFromSnapshotResource() resource.Resource {
+	return &ImageFromSnapshotResource{}
+}
+
+// ImageFromSnapshotResource defines the resource implementation.
+type ImageFromSnapshotResource struct {
+	providerData *providerData
+}
+
+// ImageFromSnapshotResourceModel describes the resource data model.
+type ImageFromSnapshotResourceModel struct {
+	ID           types.Int64  `tfsdk:"id"`
+	SnapshotID   types.Int64  `tfsdk:"snapshot_id"`
+	Name         types.String `tfsdk:"name"`
+	Tags         types.Map    `tfsdk:"tags"`
+	Region       types.String `tfsdk:"region"`
+	Project      types.String `tfsdk:"project"`
+	Owner        types.String `tfsdk:"owner"`
+	Architecture types.String `tfsdk:"architecture"`
+	Status       types.String `tfsdk:"status"`
+	CreatedAt    types.String `tfsdk:"created_at"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *ImageFromSnapshotResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_image_from_snapshot"
+}
+
+func (r *ImageFromSnapshotResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Custom VM image created from a `fakecloud_vm_snapshot`. Creation waits until the image is available. " +
+			"The image can be found with the `fakecloud_image` data source and booted with the `image_id` of a `fakecloud_virtual_machine`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Image identifier",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"snapshot_id": schema.Int64Attribute{
+				MarkdownDescription: "Identifier of the snapshot to create the image from. Changing it forces a new image to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the image",
+				Required:            true,
+			},
+			"tags": schema.MapAttribute{
+				MarkdownDescription: "Tags of the image, e.g. to find it with the `fakecloud_image` data source.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Region of the snapshot. Defaults to the provider region. Changing it forces a new image to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project of the snapshot. Defaults to the provider project. Changing it forces a new image to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"owner": schema.StringAttribute{
+				MarkdownDescription: "Owner of the image.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"architecture": schema.StringAttribute{
+				MarkdownDescription: "CPU architecture of the image, that of the snapshotted VM.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Status of the image, e.g. `available`.",
+				Computed:            true,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "Creation time of the image, in RFC 3339 format.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+func (r *ImageFromSnapshotResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *ImageFromSnapshotResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.planScope(ctx, req.Config, req.State, req.Plan, resp)
+}
+
+func (r *ImageFromSnapshotResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ImageFromSnapshotResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultImageCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	var tags map[string]string
+
+	resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tags, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	image, err := client.CreateImage(ctx, &fakecloud.Image{
+		SnapshotID: int(data.SnapshotID.ValueInt64()),
+		Name:       data.Name.ValueString(),
+		Tags:       tags,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to create image", err.Error())
+		return
+	}
+
+	data.ID = types.Int64Value(int64(image.ID))
+	saveCreated(ctx, resp, client, data.ID)
+
+	tflog.Trace(ctx, "created an image")
+
+	_, err = waitForState(ctx, r.imageStatus(client, image.ID), []string{imageStatusPending}, []string{imageStatusAvailable})
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to create image, got error waiting for it to become available: %s", err), err.Error())
+		return
+	}
+
+	image, err = client.GetImage(ctx, image.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to read image, got error: %s", err), err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(r.setImage(ctx, &data, client, image)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ImageFromSnapshotResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ImageFromSnapshotResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	image, err := client.GetImage(ctx, int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read image, got error: %s", err), err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(r.setImage(ctx, &data, client, image)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ImageFromSnapshotResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ImageFromSnapshotResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	var tags map[string]string
+
+	resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tags, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	id := int(data.ID.ValueInt64())
+
+	err := client.UpdateImage(ctx, id, data.Name.ValueString(), tags)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to update image, got error: %s", err), err.Error())
+		return
+	}
+
+	image, err := client.GetImage(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to read image, got error: %s", err), err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(r.setImage(ctx, &data, client, image)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ImageFromSnapshotResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ImageFromSnapshotResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	err := client.DeleteImage(ctx, int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to delete image, got error: %s", err), err.Error())
+		return
+	}
+}
+
+func (r *ImageFromSnapshotResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importRegional(ctx, req, resp)
+}
+
+// setImage copies the image returned by the API into the model.
+func (r *ImageFromSnapshotResource) setImage(ctx context.Context, data *ImageFromSnapshotResourceModel, client *apiClient, image *fakecloud.Image) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.SnapshotID = types.Int64Value(int64(image.SnapshotID))
+	data.Name = types.StringValue(image.Name)
+	data.Region = regionValue(client.region)
+	data.Project = projectValue(client.project)
+	data.Owner = types.StringValue(image.Owner)
+	data.Architecture = types.StringValue(image.Architecture)
+	data.Status = types.StringValue(image.Status)
+	data.CreatedAt = types.StringValue(image.CreatedAt.Format(time.RFC3339))
+
+	// Keep tags null rather than empty when none are configured.
+	if len(image.Tags) > 0 || !data.Tags.IsNull() {
+		data.Tags, diags = types.MapValueFrom(ctx, types.StringType, image.Tags)
+	}
+
+	return diags
+}
+
+// imageStatus returns a refresh function reporting the status of an image.
+func (r *ImageFromSnapshotResource) imageStatus(client *apiClient, id int) stateRefreshFunc {
+	return func(ctx context.Context) (string, error) {
+		image, err := client.GetImage(ctx, id)
+		if err != nil {
+			return "", err
+		}
+
+		return image.Status, nil
+	}
+}
@@ -0,0 +1,12 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+)
+
+func TestImageFromSnapshotResourceImportState(t *testing.T) {
+	testRegionalImportState(t, &ImageFromSnapshotResource{})
+}
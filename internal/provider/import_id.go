@@ -0,0 +1,231 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// splitImportScope splits an import identifier made of n parts separated by
+// sep from its optional scope prefix: "<region><sep>" for resources outside
+// the provider default region, or "<project><sep><region><sep>" for resources
+// outside the provider default project, in which case the region may be empty
+// to use the provider default region. Empty scopes are returned as such.
+func splitImportScope(importID string, sep string, n int) (string, string, []string, bool) {
+	parts := strings.Split(importID, sep)
+
+	switch len(parts) - n {
+	case 0:
+		return "", "", parts, true
+	case 1:
+		return "", parts[0], parts[1:], parts[0] != ""
+	case 2:
+		return parts[0], parts[1], parts[2:], parts[0] != ""
+	}
+
+	return "", "", nil, false
+}
+
+// splitImportProject splits an import identifier of a global resource made
+// of n parts separated by slashes from its optional "<project>/" prefix.
+func splitImportProject(importID string, n int) (string, []string, bool) {
+	parts := strings.Split(importID, "/")
+
+	switch len(parts) - n {
+	case 0:
+		return "", parts, true
+	case 1:
+		return parts[0], parts[1:], parts[0] != ""
+	}
+
+	return "", nil, false
+}
+
+// parseImportID parses the identifier given to `terraform import` for a
+// regional resource. It is either "<project>/<region>/<id>", "<region>/<id>",
+// or "<id>" for resources in the provider default project and region, in
+// which case the returned project and region are empty.
+func parseImportID(importID string) (string, string, int64, error) {
+	project, region, parts, ok := splitImportScope(importID, "/", 1)
+	if !ok {
+		return "", "", 0, fmt.Errorf("expected import identifier with format <project>/<region>/<id>, <region>/<id> or <id>, got %q", importID)
+	}
+
+	id, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("expected import identifier with format <project>/<region>/<id>, <region>/<id> or <id>, got %q: the identifier must be a number", importID)
+	}
+
+	return project, region, id, nil
+}
+
+// parseChildImportID parses the import identifier of a resource nested under
+// another one, e.g. a load balancer target. It is either
+// "<project>/<region>/<parent id>/<id>", "<region>/<parent id>/<id>", or
+// "<parent id>/<id>" for resources in the provider default project and
+// region, in which case the returned project and region are empty.
+func parseChildImportID(importID string) (string, string, int64, int64, error) {
+	project, region, parts, ok := splitImportScope(importID, "/", 2)
+	if !ok {
+		return "", "", 0, 0, fmt.Errorf("expected import identifier with format <project>/<region>/<parent id>/<id>, <region>/<parent id>/<id> or <parent id>/<id>, got %q", importID)
+	}
+
+	parentID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return "", "", 0, 0, fmt.Errorf("expected import identifier with format <project>/<region>/<parent id>/<id>, <region>/<parent id>/<id> or <parent id>/<id>, got %q: the parent identifier must be a number", importID)
+	}
+
+	id, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return "", "", 0, 0, fmt.Errorf("expected import identifier with format <project>/<region>/<parent id>/<id>, <region>/<parent id>/<id> or <parent id>/<id>, got %q: the identifier must be a number", importID)
+	}
+
+	return project, region, parentID, id, nil
+}
+
+// parseGlobalImportID parses the import identifier of a global resource, e.g.
+// a DNS zone. It is either "<project>/<id>", or "<id>" for resources in the
+// provider default project, in which case the returned project is empty.
+func parseGlobalImportID(importID string) (string, int64, error) {
+	project, parts, ok := splitImportProject(importID, 1)
+	if !ok {
+		return "", 0, fmt.Errorf("expected import identifier with format <project>/<id> or <id>, got %q", importID)
+	}
+
+	id, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("expected import identifier with format <project>/<id> or <id>, got %q: the identifier must be a number", importID)
+	}
+
+	return project, id, nil
+}
+
+// parseGlobalChildImportID parses the import identifier of a global resource
+// nested under another one, e.g. a DNS record. It is either
+// "<project>/<parent id>/<id>", or "<parent id>/<id>" for resources in the
+// provider default project, in which case the returned project is empty.
+func parseGlobalChildImportID(importID string) (string, int64, int64, error) {
+	project, parts, ok := splitImportProject(importID, 2)
+	if !ok {
+		return "", 0, 0, fmt.Errorf("expected import identifier with format <project>/<parent id>/<id> or <parent id>/<id>, got %q", importID)
+	}
+
+	parentID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("expected import identifier with format <project>/<parent id>/<id> or <parent id>/<id>, got %q: the parent identifier must be a number", importID)
+	}
+
+	id, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("expected import identifier with format <project>/<parent id>/<id> or <parent id>/<id>, got %q: the identifier must be a number", importID)
+	}
+
+	return project, parentID, id, nil
+}
+
+// parseBucketImportID parses the import identifier of a bucket, which is
+// identified by its name. It is either "<project>/<region>/<name>",
+// "<region>/<name>", or "<name>" for buckets in the provider default project
+// and region.
+func parseBucketImportID(importID string) (string, string, string, error) {
+	project, region, parts, ok := splitImportScope(importID, "/", 1)
+	if !ok || parts[0] == "" {
+		return "", "", "", fmt.Errorf("expected import identifier with format <project>/<region>/<name>, <region>/<name> or <name>, got %q", importID)
+	}
+
+	return project, region, parts[0], nil
+}
+
+// parseBucketObjectImportID parses the import identifier of a bucket object.
+// Object keys may contain slashes, so the scope is separated by colons: the
+// identifier is either "<project>:<region>:<bucket>/<key>",
+// "<region>:<bucket>/<key>", or "<bucket>/<key>" for buckets in the provider
+// default project and region.
+func parseBucketObjectImportID(importID string) (string, string, string, string, error) {
+	scoped, key, _ := strings.Cut(importID, "/")
+
+	project, region, parts, ok := splitImportScope(scoped, ":", 1)
+	if !ok || parts[0] == "" || key == "" {
+		return "", "", "", "", fmt.Errorf("expected import identifier with format <project>:<region>:<bucket>/<key>, <region>:<bucket>/<key> or <bucket>/<key>, got %q", importID)
+	}
+
+	return project, region, parts[0], key, nil
+}
+
+// parseIAMPolicyAttachmentID parses the identifier of an IAM policy
+// attachment, "<policy id>/<principal type>/<principal id>" where the
+// principal type is user or role, prefixed with "<project>/" outside the
+// provider default project. IAM is global, there is no region.
+func parseIAMPolicyAttachmentID(importID string) (string, int64, string, int64, error) {
+	project, parts, ok := splitImportProject(importID, 3)
+	if !ok || (parts[1] != iamPrincipalUser && parts[1] != iamPrincipalRole) {
+		return "", 0, "", 0, fmt.Errorf("expected import identifier with format [<project>/]<policy id>/user/<user id> or [<project>/]<policy id>/role/<role id>, got %q", importID)
+	}
+
+	policyID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return "", 0, "", 0, fmt.Errorf("expected import identifier with format [<project>/]<policy id>/user/<user id> or [<project>/]<policy id>/role/<role id>, got %q: the policy identifier must be a number", importID)
+	}
+
+	principalID, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return "", 0, "", 0, fmt.Errorf("expected import identifier with format [<project>/]<policy id>/user/<user id> or [<project>/]<policy id>/role/<role id>, got %q: the %s identifier must be a number", importID, parts[1])
+	}
+
+	return project, policyID, parts[1], principalID, nil
+}
+
+// importRegional imports a regional resource by the identifier parsed by
+// parseImportID.
+func importRegional(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	project, region, id, err := parseImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	setImportScope(ctx, resp, project, region)
+}
+
+// importRegionalChild imports a regional resource by the identifier parsed by
+// parseChildImportID, storing its two identifiers in the parent and child
+// attributes.
+func importRegionalChild(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, parent string, child string) {
+	project, region, parentID, childID, err := parseChildImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(parent), parentID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(child), childID)...)
+	setImportScope(ctx, resp, project, region)
+}
+
+// importGlobal imports a global resource by the identifier parsed by
+// parseGlobalImportID.
+func importGlobal(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	project, id, err := parseGlobalImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project"), projectValue(project))...)
+}
+
+// setImportScope stores the project and region parsed from the import
+// identifier of a regional resource.
+func setImportScope(ctx context.Context, resp *resource.ImportStateResponse, project string, region string) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("region"), regionValue(region))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project"), projectValue(project))...)
+}
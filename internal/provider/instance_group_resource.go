@@ -0,0 +1,672 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &InstanceGroupResource{}
+var _ resource.ResourceWithImportState = &InstanceGroupResource{}
+var _ resource.ResourceWithModifyPlan = &InstanceGroupResource{}
+var _ resource.ResourceWithValidateConfig = &InstanceGroupResource{}
+
+// Default timeouts of instance group operations when the configuration does
+// not set them. An update may replace every instance of the group.
+const (
+	defaultInstanceGroupCreateTimeout = 30 * time.Minute
+	defaultInstanceGroupUpdateTimeout = 60 * time.Minute
+)
+
+// Rolling update policy of an instance group without a rolling_update block:
+// instances are replaced one at a time, without extra capacity.
+const (
+	defaultMaxUnavailable = 1
+	defaultMaxSurge       = 0
+)
+
+func NewInstanceGroupResource() resource.Resource {
+	return &InstanceGroupResource{}
+}
+
+// InstanceGroupResource defines the resource implementation.
+type InstanceGroupResource struct {
+	providerData *providerData
+}
+
+// InstanceGroupResourceModel describes the resource data model.
+type InstanceGroupResourceModel struct {
+	ID                  types.Int64                      `tfsdk:"id"`
+	Name                types.String                     `tfsdk:"name"`
+	Region              types.String                     `tfsdk:"region"`
+	Project             types.String                     `tfsdk:"project"`
+	DesiredSize         types.Int64                      `tfsdk:"desired_size"`
+	InstanceIDs         types.List                       `tfsdk:"instance_ids"`
+	AutoscalingPolicyID types.Int64                      `tfsdk:"autoscaling_policy_id"`
+	Template            *InstanceGroupTemplateModel      `tfsdk:"template"`
+	RollingUpdate       *InstanceGroupRollingUpdateModel `tfsdk:"rolling_update"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// InstanceGroupTemplateModel describes the template block of the VMs of a
+// group.
+type InstanceGroupTemplateModel struct {
+	InstanceType types.String `tfsdk:"instance_type"`
+	ImageID      types.Int64  `tfsdk:"image_id"`
+	Tags         types.Map    `tfsdk:"tags"`
+}
+
+// InstanceGroupRollingUpdateModel describes the rolling_update block.
+type InstanceGroupRollingUpdateModel struct {
+	MaxUnavailable types.Int64 `tfsdk:"max_unavailable"`
+	MaxSurge       types.Int64 `tfsdk:"max_surge"`
+}
+
+func (r *InstanceGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_instance_group"
+}
+
+func (r *InstanceGroupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Group of identical VMs created from a template. When the template changes, the provider replaces " +
+			"the instances in batches following `rolling_update`, waiting for the new VMs of each batch to be running before the next one. " +
+			"Import with `<id>`, prefixed with `<region>/` outside the provider region, or `<project>/<region>/` outside the provider project.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Instance group identifier",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the instance group",
+				Required:            true,
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Region the instances are created in. Defaults to the provider region. Changing it forces a new instance group to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project the instance group belongs to. Defaults to the provider project. Changing it forces a new instance group to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"desired_size": schema.Int64Attribute{
+				MarkdownDescription: "Number of instances in the group. Omit it when the group is scaled by a `fakecloud_autoscaling_policy`: " +
+					"the size chosen by the autoscaler is then kept instead of planning a change, and the group is created empty. " +
+					"It is only known after the apply when an autoscaled group is updated.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 1000),
+				},
+			},
+			"instance_ids": schema.ListAttribute{
+				MarkdownDescription: "Identifiers of the VMs of the group.",
+				ElementType:         types.Int64Type,
+				Computed:            true,
+			},
+			"autoscaling_policy_id": schema.Int64Attribute{
+				MarkdownDescription: "Identifier of the `fakecloud_autoscaling_policy` scaling the group. Null when the group is not autoscaled.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"template": schema.SingleNestedBlock{
+				MarkdownDescription: "Template the VMs of the group are created from. Changing it replaces the instances following `rolling_update`.",
+				Validators: []validator.Object{
+					objectvalidator.IsRequired(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"instance_type": schema.StringAttribute{
+						MarkdownDescription: "Instance type of the VMs.",
+						Required:            true,
+					},
+					"image_id": schema.Int64Attribute{
+						MarkdownDescription: "Identifier of the image the VMs boot from, e.g. from the `fakecloud_image` data source.",
+						Optional:            true,
+					},
+					"tags": schema.MapAttribute{
+						MarkdownDescription: "Tags of the VMs.",
+						ElementType:         types.StringType,
+						Optional:            true,
+					},
+				},
+			},
+			"rolling_update": schema.SingleNestedBlock{
+				MarkdownDescription: "How instances are replaced when the template changes. Instances are replaced one at a time when omitted.",
+				Attributes: map[string]schema.Attribute{
+					"max_unavailable": schema.Int64Attribute{
+						MarkdownDescription: "Maximum number of instances below `desired_size` during the update. Defaults to `1`.",
+						Optional:            true,
+						Computed:            true,
+						Default:             int64default.StaticInt64(defaultMaxUnavailable),
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+						},
+					},
+					"max_surge": schema.Int64Attribute{
+						MarkdownDescription: "Maximum number of instances above `desired_size` during the update. Defaults to `0`.",
+						Optional:            true,
+						Computed:            true,
+						Default:             int64default.StaticInt64(defaultMaxSurge),
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+						},
+					},
+				},
+			},
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+func (r *InstanceGroupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *InstanceGroupResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data InstanceGroupResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if policy := data.RollingUpdate; policy != nil && !policy.MaxUnavailable.IsUnknown() && !policy.MaxSurge.IsUnknown() &&
+		!policy.MaxUnavailable.IsNull() && !policy.MaxSurge.IsNull() &&
+		policy.MaxUnavailable.ValueInt64()+policy.MaxSurge.ValueInt64() == 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("rolling_update"), "Invalid Rolling Update",
+			"At least one of max_unavailable and max_surge must be greater than 0, otherwise no instance can ever be replaced.")
+	}
+}
+
+func (r *InstanceGroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.planScope(ctx, req.Config, req.State, req.Plan, resp)
+
+	// Nothing more to plan when the instance group is destroyed or the
+	// provider is not configured yet.
+	if resp.Diagnostics.HasError() || req.Plan.Raw.IsNull() || r.providerData == nil {
+		return
+	}
+
+	var config, plan, state InstanceGroupResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		switch {
+		case config.DesiredSize.IsNull() && !state.AutoscalingPolicyID.IsNull() && !req.Plan.Raw.Equal(req.State.Raw):
+			// The autoscaler may still change the size before the update is
+			// applied, the size is only known then.
+			plan.DesiredSize = types.Int64Unknown()
+		case config.DesiredSize.IsNull():
+			// Keep the current size, which the autoscaler may have changed,
+			// rather than planning a change back to a previous one.
+			plan.DesiredSize = state.DesiredSize
+		case !state.AutoscalingPolicyID.IsNull() && !plan.DesiredSize.Equal(state.DesiredSize):
+			resp.Diagnostics.AddAttributeWarning(path.Root("desired_size"), "Instance Group Is Autoscaled",
+				fmt.Sprintf("The instance group is scaled by autoscaling policy %d, this apply overrides the size it chose. "+
+					"Remove desired_size from the configuration to keep the size chosen by the autoscaler.", state.AutoscalingPolicyID.ValueInt64()))
+		}
+	}
+
+	// The instances only change with the template or the size of the group.
+	if !req.State.Raw.IsNull() && plan.DesiredSize.Equal(state.DesiredSize) && plan.Template.equal(state.Template) {
+		plan.InstanceIDs = state.InstanceIDs
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *InstanceGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data InstanceGroupResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultInstanceGroupCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	// Without a configured size the group is created empty, for its
+	// autoscaling policy to scale it.
+	group := &fakecloud.InstanceGroup{
+		Name:        data.Name.ValueString(),
+		DesiredSize: int(data.DesiredSize.ValueInt64()),
+	}
+
+	resp.Diagnostics.Append(data.Template.expand(ctx, &group.Template)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group, err := client.CreateInstanceGroup(ctx, group)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to create instance group", err.Error())
+		return
+	}
+
+	data.ID = types.Int64Value(int64(group.ID))
+	saveCreated(ctx, resp, client, data.ID)
+
+	tflog.Trace(ctx, "created an instance group")
+
+	if err := waitInstancesRunning(ctx, client, memberIDs(group.Instances)); err != nil {
+		resp.Diagnostics.AddError("Error waiting for instance group instances to be running", err.Error())
+		return
+	}
+
+	group, err = client.GetInstanceGroup(ctx, group.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to read instance group, got error: %s", err), err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(setInstanceGroup(ctx, &data, client, group)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *InstanceGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data InstanceGroupResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	group, err := client.GetInstanceGroup(ctx, int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read instance group, got error: %s", err), err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(setInstanceGroup(ctx, &data, client, group)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *InstanceGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data InstanceGroupResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultInstanceGroupUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	id := int(data.ID.ValueInt64())
+
+	group := &fakecloud.InstanceGroup{
+		ID:          id,
+		Name:        data.Name.ValueString(),
+		DesiredSize: int(data.DesiredSize.ValueInt64()),
+	}
+
+	resp.Diagnostics.Append(data.Template.expand(ctx, &group.Template)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	desiredSize, err := instanceGroupDesiredSize(ctx, client, id, data.DesiredSize)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to read instance group, got error: %s", err), err.Error())
+		return
+	}
+
+	group.DesiredSize = desiredSize
+
+	// The API scales the group to the desired size right away, creating new
+	// instances from the new template. Existing instances are replaced below.
+	group, err = client.UpdateInstanceGroup(ctx, group)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to update instance group, got error: %s", err), err.Error())
+		return
+	}
+
+	if err := waitInstancesRunning(ctx, client, memberIDs(group.Instances)); err != nil {
+		resp.Diagnostics.AddError("Error waiting for instance group instances to be running", err.Error())
+		return
+	}
+
+	maxUnavailable, maxSurge := data.RollingUpdate.limits()
+
+	if err := rollInstances(ctx, client, group, maxUnavailable, maxSurge); err != nil {
+		resp.Diagnostics.AddError("Unable to replace instance group instances", err.Error())
+		return
+	}
+
+	group, err = client.GetInstanceGroup(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to read instance group, got error: %s", err), err.Error())
+		return
+	}
+
+	planned := data.DesiredSize
+
+	resp.Diagnostics.Append(setInstanceGroup(ctx, &data, client, group)...)
+
+	// The autoscaler may have scaled the group again since the update, the
+	// applied size is the planned one.
+	if !planned.IsUnknown() {
+		data.DesiredSize = planned
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *InstanceGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data InstanceGroupResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	err := client.DeleteInstanceGroup(ctx, int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to delete instance group, got error: %s", err), err.Error())
+		return
+	}
+}
+
+func (r *InstanceGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importRegional(ctx, req, resp)
+}
+
+// instanceGroupReader reads instance groups.
+type instanceGroupReader interface {
+	GetInstanceGroup(ctx context.Context, id int) (*fakecloud.InstanceGroup, error)
+}
+
+// instanceGroupDesiredSize returns the size to update group id to: the
+// planned size, or the current one when the size is left to the autoscaler
+// and only known once applied.
+func instanceGroupDesiredSize(ctx context.Context, client instanceGroupReader, id int, planned types.Int64) (int, error) {
+	if !planned.IsUnknown() {
+		return int(planned.ValueInt64()), nil
+	}
+
+	current, err := client.GetInstanceGroup(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	return current.DesiredSize, nil
+}
+
+// rollInstances replaces the instances of group created from an outdated
+// template. Each batch first adds up to maxSurge new instances, then removes
+// the outdated ones and adds the rest of their replacements, so the group
+// never has more than maxSurge instances above or maxUnavailable below its
+// desired size. New instances must be running before the next batch starts.
+func rollInstances(ctx context.Context, client *apiClient, group *fakecloud.InstanceGroup, maxUnavailable int, maxSurge int) error {
+	for _, batch := range rollingBatches(outdatedInstances(group), maxUnavailable, maxSurge) {
+		tflog.Debug(ctx, "Replacing instance group instances", map[string]interface{}{
+			"instance_group_id": group.ID,
+			"vm_ids":            batch,
+		})
+
+		surge := min(maxSurge, len(batch))
+
+		if err := addInstances(ctx, client, group.ID, surge); err != nil {
+			return err
+		}
+
+		if err := client.RemoveInstanceGroupInstances(ctx, group.ID, batch); err != nil {
+			return err
+		}
+
+		if err := addInstances(ctx, client, group.ID, len(batch)-surge); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addInstances adds count instances to a group and waits for them to be
+// running.
+func addInstances(ctx context.Context, client *apiClient, groupID int, count int) error {
+	if count == 0 {
+		return nil
+	}
+
+	ids, err := client.AddInstanceGroupInstances(ctx, groupID, count)
+	if err != nil {
+		return err
+	}
+
+	return waitInstancesRunning(ctx, client, ids)
+}
+
+// waitInstancesRunning waits for every VM of ids to be running.
+func waitInstancesRunning(ctx context.Context, client *apiClient, ids []int) error {
+	for _, id := range ids {
+		if _, err := waitForState(ctx, vmStatus(client, id), []string{vmStatusProvisioning, vmStatusStarting}, []string{vmStatusRunning}); err != nil {
+			return fmt.Errorf("VM %d: %w", id, err)
+		}
+	}
+
+	return nil
+}
+
+// outdatedInstances returns the VMs of group created from a previous version
+// of its template.
+func outdatedInstances(group *fakecloud.InstanceGroup) []int {
+	var outdated []int
+
+	for _, member := range group.Instances {
+		if member.TemplateVersion != group.TemplateVersion {
+			outdated = append(outdated, member.VMID)
+		}
+	}
+
+	return outdated
+}
+
+// rollingBatches splits the instances to replace into batches of
+// maxUnavailable + maxSurge instances.
+func rollingBatches(ids []int, maxUnavailable int, maxSurge int) [][]int {
+	size := max(maxUnavailable+maxSurge, 1)
+
+	var batches [][]int
+
+	for len(ids) > 0 {
+		n := min(size, len(ids))
+		batches = append(batches, ids[:n])
+		ids = ids[n:]
+	}
+
+	return batches
+}
+
+// memberIDs returns the VM identifiers of the members of a group.
+func memberIDs(members []fakecloud.InstanceGroupMember) []int {
+	ids := make([]int, 0, len(members))
+
+	for _, member := range members {
+		ids = append(ids, member.VMID)
+	}
+
+	return ids
+}
+
+// limits returns the rolling update limits, the defaults when the
+// rolling_update block is omitted.
+func (m *InstanceGroupRollingUpdateModel) limits() (int, int) {
+	if m == nil {
+		return defaultMaxUnavailable, defaultMaxSurge
+	}
+
+	return int(m.MaxUnavailable.ValueInt64()), int(m.MaxSurge.ValueInt64())
+}
+
+// equal reports whether two template blocks are the same.
+func (m *InstanceGroupTemplateModel) equal(other *InstanceGroupTemplateModel) bool {
+	if m == nil || other == nil {
+		return m == other
+	}
+
+	return m.InstanceType.Equal(other.InstanceType) && m.ImageID.Equal(other.ImageID) && m.Tags.Equal(other.Tags)
+}
+
+// expand converts the template block into the template sent to the API.
+func (m *InstanceGroupTemplateModel) expand(ctx context.Context, template *fakecloud.InstanceTemplate) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	template.InstanceType = m.InstanceType.ValueString()
+	template.ImageID = int(m.ImageID.ValueInt64())
+
+	if !m.Tags.IsNull() {
+		diags.Append(m.Tags.ElementsAs(ctx, &template.Tags, false)...)
+	}
+
+	return diags
+}
+
+// setInstanceGroup copies the instance group returned by the API into the
+// model.
+func setInstanceGroup(ctx context.Context, data *InstanceGroupResourceModel, client *apiClient, group *fakecloud.InstanceGroup) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.ID = types.Int64Value(int64(group.ID))
+	data.Name = types.StringValue(group.Name)
+	data.Region = regionValue(client.region)
+	data.Project = projectValue(client.project)
+	data.DesiredSize = types.Int64Value(int64(group.DesiredSize))
+
+	data.InstanceIDs, diags = types.ListValueFrom(ctx, types.Int64Type, memberIDs(group.Instances))
+
+	data.AutoscalingPolicyID = types.Int64Null()
+	if group.AutoscalingPolicyID != 0 {
+		data.AutoscalingPolicyID = types.Int64Value(int64(group.AutoscalingPolicyID))
+	}
+
+	template := &InstanceGroupTemplateModel{
+		InstanceType: types.StringValue(group.Template.InstanceType),
+		ImageID:      types.Int64Null(),
+		Tags:         types.MapNull(types.StringType),
+	}
+
+	if group.Template.ImageID != 0 {
+		template.ImageID = types.Int64Value(int64(group.Template.ImageID))
+	}
+
+	// Keep tags null rather than empty when not configured.
+	if len(group.Template.Tags) > 0 || (data.Template != nil && !data.Template.Tags.IsNull()) {
+		tags, d := types.MapValueFrom(ctx, types.StringType, group.Template.Tags)
+		diags.Append(d...)
+		template.Tags = tags
+	}
+
+	data.Template = template
+
+	return diags
+}
@@ -0,0 +1,205 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+func TestRollingBatches(t *testing.T) {
+	cases := map[string]struct {
+		ids            []int
+		maxUnavailable int
+		maxSurge       int
+		expected       [][]int
+	}{
+		"one at a time": {
+			ids:            []int{1, 2, 3},
+			maxUnavailable: 1,
+			expected:       [][]int{{1}, {2}, {3}},
+		},
+		"unavailable and surge": {
+			ids:            []int{1, 2, 3, 4, 5},
+			maxUnavailable: 1,
+			maxSurge:       1,
+			expected:       [][]int{{1, 2}, {3, 4}, {5}},
+		},
+		"surge only": {
+			ids:      []int{1, 2, 3},
+			maxSurge: 2,
+			expected: [][]int{{1, 2}, {3}},
+		},
+		"larger than group": {
+			ids:            []int{1, 2},
+			maxUnavailable: 5,
+			expected:       [][]int{{1, 2}},
+		},
+		"nothing to replace": {
+			maxUnavailable: 1,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			batches := rollingBatches(c.ids, c.maxUnavailable, c.maxSurge)
+
+			if diff := cmp.Diff(c.expected, batches); diff != "" {
+				t.Errorf("unexpected batches (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOutdatedInstances(t *testing.T) {
+	group := &fakecloud.InstanceGroup{
+		TemplateVersion: 3,
+		Instances: []fakecloud.InstanceGroupMember{
+			{VMID: 10, TemplateVersion: 2},
+			{VMID: 11, TemplateVersion: 3},
+			{VMID: 12, TemplateVersion: 1},
+		},
+	}
+
+	if diff := cmp.Diff([]int{10, 12}, outdatedInstances(group)); diff != "" {
+		t.Errorf("unexpected outdated instances (-expected +actual):\n%s", diff)
+	}
+}
+
+func TestInstanceGroupResourceModifyPlanDesiredSize(t *testing.T) {
+	cases := map[string]struct {
+		configured types.Int64
+		name       string
+		policyID   types.Int64
+		expected   types.Int64
+	}{
+		"configured": {
+			configured: types.Int64Value(5),
+			name:       "web",
+			policyID:   types.Int64Null(),
+			expected:   types.Int64Value(5),
+		},
+		"not configured": {
+			configured: types.Int64Null(),
+			name:       "api",
+			policyID:   types.Int64Null(),
+			expected:   types.Int64Value(3),
+		},
+		"autoscaled unchanged": {
+			configured: types.Int64Null(),
+			name:       "web",
+			policyID:   types.Int64Value(7),
+			expected:   types.Int64Value(3),
+		},
+		"autoscaled updated": {
+			configured: types.Int64Null(),
+			name:       "api",
+			policyID:   types.Int64Value(7),
+			expected:   types.Int64Unknown(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			r := &InstanceGroupResource{providerData: &providerData{region: "eu-central"}}
+
+			group := func(name string, desiredSize types.Int64) map[string]attr.Value {
+				return map[string]attr.Value{
+					"id":                    types.Int64Value(42),
+					"name":                  types.StringValue(name),
+					"region":                types.StringValue("eu-central"),
+					"desired_size":          desiredSize,
+					"instance_ids":          types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(1), types.Int64Value(2), types.Int64Value(3)}),
+					"autoscaling_policy_id": tc.policyID,
+				}
+			}
+
+			// Without a configured size, the proposed plan keeps the size
+			// from state.
+			planned := tc.configured
+			if planned.IsNull() {
+				planned = types.Int64Value(3)
+			}
+
+			config := testResourceState(t, r, map[string]attr.Value{"name": types.StringValue(tc.name), "desired_size": tc.configured})
+			plan := testResourceState(t, r, group(tc.name, planned))
+
+			req := resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw},
+				Plan:   tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+				State:  testResourceState(t, r, group("web", types.Int64Value(3))),
+			}
+			resp := resource.ModifyPlanResponse{Plan: req.Plan}
+
+			r.ModifyPlan(ctx, req, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			var actual types.Int64
+			if diags := resp.Plan.GetAttribute(ctx, path.Root("desired_size"), &actual); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if !actual.Equal(tc.expected) {
+				t.Errorf("expected desired_size %s, got %s", tc.expected, actual)
+			}
+		})
+	}
+}
+
+// stubInstanceGroupReader answers GetInstanceGroup with group.
+type stubInstanceGroupReader struct {
+	group *fakecloud.InstanceGroup
+	reads int
+}
+
+func (c *stubInstanceGroupReader) GetInstanceGroup(ctx context.Context, id int) (*fakecloud.InstanceGroup, error) {
+	c.reads++
+	return c.group, nil
+}
+
+func TestInstanceGroupDesiredSize(t *testing.T) {
+	cases := map[string]struct {
+		planned  types.Int64
+		expected int
+		reads    int
+	}{
+		"planned": {
+			planned:  types.Int64Value(3),
+			expected: 3,
+		},
+		"left to the autoscaler": {
+			planned:  types.Int64Unknown(),
+			expected: 5,
+			reads:    1,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// The autoscaler scaled the group from 3 to 5 since the plan.
+			client := &stubInstanceGroupReader{group: &fakecloud.InstanceGroup{ID: 42, DesiredSize: 5}}
+
+			actual, err := instanceGroupDesiredSize(context.Background(), client, 42, tc.planned)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if actual != tc.expected || client.reads != tc.reads {
+				t.Errorf("expected size %d after %d reads, got %d after %d", tc.expected, tc.reads, actual, client.reads)
+			}
+		})
+	}
+}
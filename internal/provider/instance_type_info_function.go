@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &InstanceTypeInfoFunction{}
+
+func NewInstanceTypeInfoFunction() function.Function {
+	return &InstanceTypeInfoFunction{}
+}
+
+// InstanceTypeInfoFunction defines the instance_type_info function implementation.
+type InstanceTypeInfoFunction struct{}
+
+// instanceTypeInfoResultTypes describes the object returned by instance_type_info.
+var instanceTypeInfoResultTypes = map[string]attr.Type{
+	"name":         types.StringType,
+	"family":       types.StringType,
+	"size":         types.StringType,
+	"vcpus":        types.Int64Type,
+	"memory_gb":    types.Int64Type,
+	"architecture": types.StringType,
+}
+
+func (f *InstanceTypeInfoFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "instance_type_info"
+}
+
+func (f *InstanceTypeInfoFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Describe an instance type",
+		MarkdownDescription: "Returns the `family`, `size`, `vcpus`, `memory_gb` and `architecture` of a Fakecloud instance type " +
+			"such as `standard.large`. Fails for instance types that are not offered by Fakecloud.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "instance_type",
+				MarkdownDescription: "Instance type name, of the form `<family>.<size>`.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: instanceTypeInfoResultTypes,
+		},
+	}
+}
+
+func (f *InstanceTypeInfoFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &name))
+	if resp.Error != nil {
+		return
+	}
+
+	info, err := lookupInstanceType(name)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0, err.Error()))
+		return
+	}
+
+	result, diags := types.ObjectValue(instanceTypeInfoResultTypes, map[string]attr.Value{
+		"name":         types.StringValue(info.Name),
+		"family":       types.StringValue(info.Family),
+		"size":         types.StringValue(info.Size),
+		"vcpus":        types.Int64Value(info.VCPUs),
+		"memory_gb":    types.Int64Value(info.MemoryGB),
+		"architecture": types.StringValue(info.Architecture),
+	})
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestInstanceTypeInfoFunctionRun(t *testing.T) {
+	cases := map[string]struct {
+		request  function.RunRequest
+		expected function.RunResponse
+	}{
+		"standard": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("standard.large")}),
+			},
+			expected: function.RunResponse{
+				Result: function.NewResultData(types.ObjectValueMust(instanceTypeInfoResultTypes, map[string]attr.Value{
+					"name":         types.StringValue("standard.large"),
+					"family":       types.StringValue("standard"),
+					"size":         types.StringValue("large"),
+					"vcpus":        types.Int64Value(4),
+					"memory_gb":    types.Int64Value(16),
+					"architecture": types.StringValue("x86_64"),
+				})),
+			},
+		},
+		"arm": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("arm.small")}),
+			},
+			expected: function.RunResponse{
+				Result: function.NewResultData(types.ObjectValueMust(instanceTypeInfoResultTypes, map[string]attr.Value{
+					"name":         types.StringValue("arm.small"),
+					"family":       types.StringValue("arm"),
+					"size":         types.StringValue("small"),
+					"vcpus":        types.Int64Value(1),
+					"memory_gb":    types.Int64Value(4),
+					"architecture": types.StringValue("arm64"),
+				})),
+			},
+		},
+		"unknown size": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("memory.huge")}),
+			},
+			expected: function.RunResponse{
+				Error:  function.NewArgumentFuncError(0, `invalid instance type "memory.huge": unknown size "huge", expected one of 2xlarge, large, medium, small, xlarge`),
+				Result: function.NewResultData(types.ObjectUnknown(instanceTypeInfoResultTypes)),
+			},
+		},
+		"malformed": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("large")}),
+			},
+			expected: function.RunResponse{
+				Error:  function.NewArgumentFuncError(0, `invalid instance type "large": expected <family>.<size>, e.g. standard.large`),
+				Result: function.NewResultData(types.ObjectUnknown(instanceTypeInfoResultTypes)),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := function.RunResponse{
+				Result: function.NewResultData(types.ObjectUnknown(instanceTypeInfoResultTypes)),
+			}
+
+			NewInstanceTypeInfoFunction().Run(context.Background(), tc.request, &got)
+
+			if !got.Error.Equal(tc.expected.Error) {
+				t.Errorf("expected error %v, got %v", tc.expected.Error, got.Error)
+			}
+
+			if !got.Result.Equal(tc.expected.Result) {
+				t.Errorf("expected result %s, got %s", tc.expected.Result.Value(), got.Result.Value())
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// instanceType describes a Fakecloud instance type. Instance type names have
+// the form <family>.<size>, e.g. standard.large.
+type instanceType struct {
+	Name         string
+	Family       string
+	Size         string
+	VCPUs        int64
+	MemoryGB     int64
+	Architecture string
+}
+
+// instanceFamily describes the hardware shared by the instance types of a
+// family.
+type instanceFamily struct {
+	Architecture  string
+	MemoryPerVCPU int64
+}
+
+// instanceFamilies is the catalog of instance families offered by Fakecloud.
+var instanceFamilies = map[string]instanceFamily{
+	"standard": {Architecture: "x86_64", MemoryPerVCPU: 4},
+	"compute":  {Architecture: "x86_64", MemoryPerVCPU: 2},
+	"memory":   {Architecture: "x86_64", MemoryPerVCPU: 8},
+	"arm":      {Architecture: "arm64", MemoryPerVCPU: 4},
+}
+
+// instanceSizes maps the sizes every family is offered in to their vCPU count.
+var instanceSizes = map[string]int64{
+	"small":   1,
+	"medium":  2,
+	"large":   4,
+	"xlarge":  8,
+	"2xlarge": 16,
+}
+
+// lookupInstanceType returns the catalog entry for the instance type name.
+func lookupInstanceType(name string) (instanceType, error) {
+	familyName, size, found := strings.Cut(name, ".")
+	if !found {
+		return instanceType{}, fmt.Errorf("invalid instance type %q: expected <family>.<size>, e.g. standard.large", name)
+	}
+
+	family, ok := instanceFamilies[familyName]
+	if !ok {
+		return instanceType{}, fmt.Errorf("invalid instance type %q: unknown family %q, expected one of %s", name, familyName, strings.Join(sortedKeys(instanceFamilies), ", "))
+	}
+
+	vcpus, ok := instanceSizes[size]
+	if !ok {
+		return instanceType{}, fmt.Errorf("invalid instance type %q: unknown size %q, expected one of %s", name, size, strings.Join(sortedKeys(instanceSizes), ", "))
+	}
+
+	return instanceType{
+		Name:         name,
+		Family:       familyName,
+		Size:         size,
+		VCPUs:        vcpus,
+		MemoryGB:     vcpus * family.MemoryPerVCPU,
+		Architecture: family.Architecture,
+	}, nil
+}
+
+var _ validator.String = instanceTypeValidator{}
+
+// instanceTypeValidator validates that a string is an instance type of the
+// catalog.
+type instanceTypeValidator struct{}
+
+func (v instanceTypeValidator) Description(ctx context.Context) string {
+	return "value must be an instance type, e.g. standard.large"
+}
+
+func (v instanceTypeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v instanceTypeValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := lookupInstanceType(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Instance Type", err.Error())
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// resizeMode describes how a VM moves from one instance type to another.
+type resizeMode int
+
+const (
+	// resizeLive resizes the VM while it keeps running.
+	resizeLive resizeMode = iota
+	// resizeStopped requires the VM to be stopped during the resize.
+	resizeStopped
+	// resizeUnsupported requires the VM to be replaced.
+	resizeUnsupported
+)
+
+// resizeModeBetween returns how a VM is resized between two instance types.
+// Types within a family resize live, types of the same architecture need the
+// VM to be stopped and a change of architecture is not possible in place.
+// Types missing from the catalog are assumed to need a stop and left to the
+// API to validate.
+func resizeModeBetween(from string, to string) resizeMode {
+	fromType, fromErr := lookupInstanceType(from)
+	toType, toErr := lookupInstanceType(to)
+
+	switch {
+	case fromErr != nil || toErr != nil:
+		return resizeStopped
+	case fromType.Architecture != toType.Architecture:
+		return resizeUnsupported
+	case fromType.Family != toType.Family:
+		return resizeStopped
+	default:
+		return resizeLive
+	}
+}
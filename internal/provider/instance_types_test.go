@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestResizeModeBetween(t *testing.T) {
+	cases := map[string]struct {
+		from     string
+		to       string
+		expected resizeMode
+	}{
+		"same family": {
+			from:     "standard.small",
+			to:       "standard.xlarge",
+			expected: resizeLive,
+		},
+		"other family": {
+			from:     "standard.large",
+			to:       "memory.large",
+			expected: resizeStopped,
+		},
+		"other architecture": {
+			from:     "standard.large",
+			to:       "arm.large",
+			expected: resizeUnsupported,
+		},
+		"unknown type": {
+			from:     "standard.large",
+			to:       "gpu.large",
+			expected: resizeStopped,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := resizeModeBetween(tc.from, tc.to); got != tc.expected {
+				t.Errorf("expected %d, got %d", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestInstanceTypeValidator(t *testing.T) {
+	cases := map[string]struct {
+		value types.String
+		err   bool
+	}{
+		"valid": {
+			value: types.StringValue("arm.xlarge"),
+		},
+		"unknown family": {
+			value: types.StringValue("gpu.large"),
+			err:   true,
+		},
+		"unknown size": {
+			value: types.StringValue("standard.huge"),
+			err:   true,
+		},
+		"no size": {
+			value: types.StringValue("standard"),
+			err:   true,
+		},
+		"null": {
+			value: types.StringNull(),
+		},
+		"unknown": {
+			value: types.StringUnknown(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := validator.StringRequest{
+				Path:        path.Root("instance_type"),
+				ConfigValue: tc.value,
+			}
+			resp := &validator.StringResponse{}
+
+			instanceTypeValidator{}.ValidateString(context.Background(), req, resp)
+
+			if got := resp.Diagnostics.HasError(); got != tc.err {
+				t.Errorf("expected error %t, got diagnostics %v", tc.err, resp.Diagnostics)
+			}
+		})
+	}
+}
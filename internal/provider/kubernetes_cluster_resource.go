@@ -0,0 +1,383 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &KubernetesClusterResource{}
+var _ resource.ResourceWithImportState = &KubernetesClusterResource{}
+var _ resource.ResourceWithModifyPlan = &KubernetesClusterResource{}
+
+// Statuses of Kubernetes clusters and node pools.
+const (
+	kubernetesStatusProvisioning = "provisioning"
+	kubernetesStatusRunning      = "running"
+	kubernetesStatusUpdating     = "updating"
+)
+
+// Default timeouts of Kubernetes cluster operations when the configuration
+// does not set them. An update upgrades the control plane.
+const (
+	defaultKubernetesClusterCreateTimeout = 30 * time.Minute
+	defaultKubernetesClusterUpdateTimeout = 60 * time.Minute
+)
+
+func NewKubernetesClusterResource() resource.Resource {
+	return &KubernetesClusterResource{}
+}
+
+// KubernetesClusterResource defines the resource implementation.
+type KubernetesClusterResource struct {
+	providerData *providerData
+}
+
+// KubernetesClusterResourceModel describes the resource data model.
+type KubernetesClusterResourceModel struct {
+	ID                   types.Int64  `tfsdk:"id"`
+	Name                 types.String `tfsdk:"name"`
+	Region               types.String `tfsdk:"region"`
+	Project              types.String `tfsdk:"project"`
+	Version              types.String `tfsdk:"version"`
+	Endpoint             types.String `tfsdk:"endpoint"`
+	ClusterCACertificate types.String `tfsdk:"cluster_ca_certificate"`
+	Kubeconfig           types.String `tfsdk:"kubeconfig"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *KubernetesClusterResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_kubernetes_cluster"
+}
+
+func (r *KubernetesClusterResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Managed Kubernetes cluster. The cluster only runs the control plane, worker nodes are added with " +
+			"`fakecloud_kubernetes_node_pool`. The provider waits for the cluster to be running after creating or upgrading it. " +
+			"Import with `<id>`, prefixed with `<region>/` outside the provider region, or `<project>/<region>/` outside the provider project.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Kubernetes cluster identifier",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the cluster. Changing it forces a new cluster to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Region the cluster is created in. Defaults to the provider region. Changing it forces a new cluster to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project the cluster belongs to. Defaults to the provider project. Changing it forces a new cluster to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "Kubernetes version of the control plane, e.g. `1.31`. Defaults to the latest version offered by Fakecloud. " +
+					"Changing it upgrades the cluster in place, the API rejects downgrades.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "URL of the Kubernetes API server. Unknown in a plan changing `version`, known again once the upgrade is applied.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster_ca_certificate": schema.StringAttribute{
+				MarkdownDescription: "Base64 encoded PEM certificate of the authority signing the API server certificate. " +
+					"Unknown in a plan changing `version`, known again once the upgrade is applied.",
+				Computed:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"kubeconfig": schema.StringAttribute{
+				MarkdownDescription: "Kubeconfig file granting administrator access to the cluster. Unknown in a plan changing `version`, known again once the upgrade is applied.",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+func (r *KubernetesClusterResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *KubernetesClusterResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.planScope(ctx, req.Config, req.State, req.Plan, resp)
+
+	// Nothing more to plan unless the cluster is updated.
+	if resp.Diagnostics.HasError() || req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state KubernetesClusterResourceModel
+
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An upgrade may move the API server and rotate its certificates, the
+	// access details are only known once it is done.
+	if !plan.Version.Equal(state.Version) {
+		plan.Endpoint = types.StringUnknown()
+		plan.ClusterCACertificate = types.StringUnknown()
+		plan.Kubeconfig = types.StringUnknown()
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *KubernetesClusterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data KubernetesClusterResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultKubernetesClusterCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	cluster, err := client.CreateKubernetesCluster(ctx, &fakecloud.KubernetesCluster{
+		Name:    data.Name.ValueString(),
+		Version: data.Version.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to create Kubernetes cluster", err.Error())
+		return
+	}
+
+	data.ID = types.Int64Value(int64(cluster.ID))
+	saveCreated(ctx, resp, client, data.ID)
+
+	tflog.Trace(ctx, "created a Kubernetes cluster")
+
+	cluster, err = waitKubernetesClusterRunning(ctx, kubernetesClusterRefresh(client, cluster.ID), kubernetesStatusProvisioning, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error waiting for Kubernetes cluster to be running", err.Error())
+		return
+	}
+
+	setKubernetesCluster(&data, client, cluster)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *KubernetesClusterResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data KubernetesClusterResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	cluster, err := client.GetKubernetesCluster(ctx, int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read Kubernetes cluster, got error: %s", err), err.Error())
+		return
+	}
+
+	setKubernetesCluster(&data, client, cluster)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *KubernetesClusterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state KubernetesClusterResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultKubernetesClusterUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	id := int(data.ID.ValueInt64())
+
+	// The version is the only attribute updated in place, anything else only
+	// changes the timeouts.
+	if !data.Version.Equal(state.Version) {
+		err := client.UpdateKubernetesCluster(ctx, id, data.Version.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Unable to upgrade Kubernetes cluster, got error: %s", err), err.Error())
+			return
+		}
+	}
+
+	// The API may still report the cluster running with the previous version
+	// right after the upgrade request.
+	upgraded := func(cluster *fakecloud.KubernetesCluster) bool {
+		return cluster.Version == data.Version.ValueString()
+	}
+
+	cluster, err := waitKubernetesClusterRunning(ctx, kubernetesClusterRefresh(client, id), kubernetesStatusUpdating, upgraded)
+	if err != nil {
+		resp.Diagnostics.AddError("Error waiting for Kubernetes cluster to be running", err.Error())
+		return
+	}
+
+	setKubernetesCluster(&data, client, cluster)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *KubernetesClusterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data KubernetesClusterResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	// The API refuses to delete a cluster that still has node pools.
+	err := client.DeleteKubernetesCluster(ctx, int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to delete Kubernetes cluster, got error: %s", err), err.Error())
+		return
+	}
+}
+
+func (r *KubernetesClusterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importRegional(ctx, req, resp)
+}
+
+// waitKubernetesClusterRunning waits for a cluster leaving the pending
+// status to be running with the changes checked by applied, if any, and
+// returns it.
+func waitKubernetesClusterRunning(ctx context.Context, refresh func(ctx context.Context) (*fakecloud.KubernetesCluster, error), pending string, applied func(*fakecloud.KubernetesCluster) bool) (*fakecloud.KubernetesCluster, error) {
+	status := func(cluster *fakecloud.KubernetesCluster) string { return cluster.Status }
+
+	cluster, err := waitForChange(ctx, refresh, status, applied, []string{pending}, []string{kubernetesStatusRunning})
+	if err != nil {
+		return nil, err
+	}
+
+	return cluster, nil
+}
+
+// kubernetesClusterRefresh returns a function reading a cluster, to wait on.
+func kubernetesClusterRefresh(client *apiClient, id int) func(ctx context.Context) (*fakecloud.KubernetesCluster, error) {
+	return func(ctx context.Context) (*fakecloud.KubernetesCluster, error) {
+		return client.GetKubernetesCluster(ctx, id)
+	}
+}
+
+// setKubernetesCluster copies the cluster returned by the API into the model.
+func setKubernetesCluster(data *KubernetesClusterResourceModel, client *apiClient, cluster *fakecloud.KubernetesCluster) {
+	data.ID = types.Int64Value(int64(cluster.ID))
+	data.Name = types.StringValue(cluster.Name)
+	data.Region = regionValue(client.region)
+	data.Project = projectValue(client.project)
+	data.Version = types.StringValue(cluster.Version)
+	data.Endpoint = types.StringValue(cluster.Endpoint)
+	data.ClusterCACertificate = types.StringValue(cluster.ClusterCACertificate)
+	data.Kubeconfig = types.StringValue(cluster.Kubeconfig)
+}
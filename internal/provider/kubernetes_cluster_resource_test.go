@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+func TestWaitKubernetesClusterRunning(t *testing.T) {
+	defer func(interval time.Duration) { waitPollInterval = interval }(waitPollInterval)
+	waitPollInterval = time.Millisecond
+
+	cluster := func(status string, version string) *fakecloud.KubernetesCluster {
+		return &fakecloud.KubernetesCluster{Status: status, Version: version}
+	}
+
+	upgraded := func(cluster *fakecloud.KubernetesCluster) bool { return cluster.Version == "1.31" }
+
+	cases := map[string]struct {
+		clusters []*fakecloud.KubernetesCluster
+		pending  string
+		applied  func(*fakecloud.KubernetesCluster) bool
+		polls    int
+		err      bool
+	}{
+		"created": {
+			clusters: []*fakecloud.KubernetesCluster{cluster("provisioning", "1.31"), cluster("running", "1.31")},
+			pending:  "provisioning",
+			polls:    2,
+		},
+		"upgrade not started yet": {
+			clusters: []*fakecloud.KubernetesCluster{cluster("running", "1.30"), cluster("updating", "1.30"), cluster("running", "1.31")},
+			pending:  "updating",
+			applied:  upgraded,
+			polls:    3,
+		},
+		"upgrade without updating status": {
+			clusters: []*fakecloud.KubernetesCluster{cluster("running", "1.30"), cluster("running", "1.31")},
+			pending:  "updating",
+			applied:  upgraded,
+			polls:    2,
+		},
+		"upgrade never applied": {
+			clusters: []*fakecloud.KubernetesCluster{cluster("running", "1.30")},
+			pending:  "updating",
+			applied:  upgraded,
+			err:      true,
+		},
+		"upgrade failed": {
+			clusters: []*fakecloud.KubernetesCluster{cluster("updating", "1.30"), cluster("error", "1.30")},
+			pending:  "updating",
+			applied:  upgraded,
+			err:      true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+
+			polls := 0
+			next := sequenceObjects(tc.clusters...)
+			refresh := func(ctx context.Context) (*fakecloud.KubernetesCluster, error) {
+				polls++
+				return next(ctx)
+			}
+
+			_, err := waitKubernetesClusterRunning(ctx, refresh, tc.pending, tc.applied)
+			if tc.err {
+				if err == nil {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if polls != tc.polls {
+				t.Errorf("expected the wait to return after %d polls, got %d", tc.polls, polls)
+			}
+		})
+	}
+}
+
+func TestKubernetesClusterResourceModifyPlanAccess(t *testing.T) {
+	cases := map[string]struct {
+		version string
+		unknown bool
+	}{
+		"version unchanged": {
+			version: "1.30",
+		},
+		"version upgraded": {
+			version: "1.31",
+			unknown: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			r := &KubernetesClusterResource{providerData: &providerData{region: "eu-central"}}
+
+			cluster := func(version string) map[string]attr.Value {
+				return map[string]attr.Value{
+					"id":                     types.Int64Value(42),
+					"name":                   types.StringValue("prod"),
+					"region":                 types.StringValue("eu-central"),
+					"version":                types.StringValue(version),
+					"endpoint":               types.StringValue("https://k8s.fakecloud.test"),
+					"cluster_ca_certificate": types.StringValue("Y2E="),
+					"kubeconfig":             types.StringValue("apiVersion: v1"),
+				}
+			}
+
+			// The access details are planned from state by UseStateForUnknown.
+			planned := testResourceState(t, r, cluster(tc.version))
+
+			req := resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: planned.Schema, Raw: planned.Raw},
+				Plan:   tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw},
+				State:  testResourceState(t, r, cluster("1.30")),
+			}
+			resp := resource.ModifyPlanResponse{Plan: req.Plan}
+
+			r.ModifyPlan(ctx, req, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			for _, name := range []string{"endpoint", "cluster_ca_certificate", "kubeconfig"} {
+				var actual types.String
+				if diags := resp.Plan.GetAttribute(ctx, path.Root(name), &actual); diags.HasError() {
+					t.Fatalf("unexpected error: %v", diags)
+				}
+
+				if actual.IsUnknown() != tc.unknown {
+					t.Errorf("expected %s unknown %t, got %s", name, tc.unknown, actual)
+				}
+			}
+		})
+	}
+}
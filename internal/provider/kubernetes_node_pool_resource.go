@@ -0,0 +1,389 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &KubernetesNodePoolResource{}
+var _ resource.ResourceWithImportState = &KubernetesNodePoolResource{}
+var _ resource.ResourceWithModifyPlan = &KubernetesNodePoolResource{}
+
+// Default timeouts of node pool operations when the configuration does not
+// set them.
+const (
+	defaultKubernetesNodePoolCreateTimeout = 30 * time.Minute
+	defaultKubernetesNodePoolUpdateTimeout = 30 * time.Minute
+)
+
+func NewKubernetesNodePoolResource() resource.Resource {
+	return &KubernetesNodePoolResource{}
+}
+
+// KubernetesNodePoolResource defines the resource implementation.
+type KubernetesNodePoolResource struct {
+	providerData *providerData
+}
+
+// KubernetesNodePoolResourceModel describes the resource data model.
+type KubernetesNodePoolResourceModel struct {
+	ID           types.Int64  `tfsdk:"id"`
+	ClusterID    types.Int64  `tfsdk:"cluster_id"`
+	Name         types.String `tfsdk:"name"`
+	Region       types.String `tfsdk:"region"`
+	Project      types.String `tfsdk:"project"`
+	InstanceType types.String `tfsdk:"instance_type"`
+	NodeCount    types.Int64  `tfsdk:"node_count"`
+	Labels       types.Map    `tfsdk:"labels"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *KubernetesNodePoolResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_kubernetes_node_pool"
+}
+
+func (r *KubernetesNodePoolResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Group of identical worker nodes of a `fakecloud_kubernetes_cluster`. The provider waits for the nodes to be running " +
+			"after creating or resizing the pool. Import with `<cluster_id>/<id>`, prefixed with `<region>/` outside the provider region, " +
+			"or `<project>/<region>/` outside the provider project.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Node pool identifier",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster_id": schema.Int64Attribute{
+				MarkdownDescription: "Identifier of the Kubernetes cluster. Changing it forces a new node pool to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the node pool, unique within the cluster. Changing it forces a new node pool to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Region of the cluster. Defaults to the provider region. Changing it forces a new node pool to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project of the cluster. Defaults to the provider project. Changing it forces a new node pool to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"instance_type": schema.StringAttribute{
+				MarkdownDescription: "Instance type of the nodes, e.g. `standard.large`. Changing it forces a new node pool to be created.",
+				Required:            true,
+				Validators: []validator.String{
+					instanceTypeValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"node_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of nodes in the pool, between 1 and 100.",
+				Required:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 100),
+				},
+			},
+			"labels": schema.MapAttribute{
+				MarkdownDescription: "Kubernetes labels applied to the nodes of the pool.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+func (r *KubernetesNodePoolResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *KubernetesNodePoolResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.planScope(ctx, req.Config, req.State, req.Plan, resp)
+}
+
+func (r *KubernetesNodePoolResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data KubernetesNodePoolResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultKubernetesNodePoolCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	pool := &fakecloud.KubernetesNodePool{
+		ClusterID:    int(data.ClusterID.ValueInt64()),
+		Name:         data.Name.ValueString(),
+		InstanceType: data.InstanceType.ValueString(),
+		NodeCount:    int(data.NodeCount.ValueInt64()),
+	}
+
+	if !data.Labels.IsNull() {
+		resp.Diagnostics.Append(data.Labels.ElementsAs(ctx, &pool.Labels, false)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	pool, err := client.CreateKubernetesNodePool(ctx, pool)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to create Kubernetes node pool", err.Error())
+		return
+	}
+
+	data.ID = types.Int64Value(int64(pool.ID))
+	saveCreated(ctx, resp, client, data.ID)
+
+	// Node pools are read through their cluster.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_id"), data.ClusterID)...)
+
+	tflog.Trace(ctx, "created a Kubernetes node pool")
+
+	pool, err = waitKubernetesNodePoolRunning(ctx, kubernetesNodePoolRefresh(client, pool.ClusterID, pool.ID), kubernetesStatusProvisioning, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error waiting for Kubernetes node pool to be running", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(setKubernetesNodePool(ctx, &data, client, pool)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *KubernetesNodePoolResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data KubernetesNodePoolResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	pool, err := client.GetKubernetesNodePool(ctx, int(data.ClusterID.ValueInt64()), int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read Kubernetes node pool, got error: %s", err), err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(setKubernetesNodePool(ctx, &data, client, pool)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *KubernetesNodePoolResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data KubernetesNodePoolResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultKubernetesNodePoolUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	pool := &fakecloud.KubernetesNodePool{
+		ID:           int(data.ID.ValueInt64()),
+		ClusterID:    int(data.ClusterID.ValueInt64()),
+		Name:         data.Name.ValueString(),
+		InstanceType: data.InstanceType.ValueString(),
+		NodeCount:    int(data.NodeCount.ValueInt64()),
+	}
+
+	if !data.Labels.IsNull() {
+		resp.Diagnostics.Append(data.Labels.ElementsAs(ctx, &pool.Labels, false)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	err := client.UpdateKubernetesNodePool(ctx, pool)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to update Kubernetes node pool, got error: %s", err), err.Error())
+		return
+	}
+
+	pool, err = waitKubernetesNodePoolRunning(ctx, kubernetesNodePoolRefresh(client, pool.ClusterID, pool.ID), kubernetesStatusUpdating, kubernetesNodePoolUpdated(pool))
+	if err != nil {
+		resp.Diagnostics.AddError("Error waiting for Kubernetes node pool to be running", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(setKubernetesNodePool(ctx, &data, client, pool)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *KubernetesNodePoolResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data KubernetesNodePoolResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	err := client.DeleteKubernetesNodePool(ctx, int(data.ClusterID.ValueInt64()), int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to delete Kubernetes node pool, got error: %s", err), err.Error())
+		return
+	}
+}
+
+func (r *KubernetesNodePoolResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importRegionalChild(ctx, req, resp, "cluster_id", "id")
+}
+
+// waitKubernetesNodePoolRunning waits for a node pool leaving the pending
+// status to be running with the changes checked by applied, if any, and
+// returns it.
+func waitKubernetesNodePoolRunning(ctx context.Context, refresh func(ctx context.Context) (*fakecloud.KubernetesNodePool, error), pending string, applied func(*fakecloud.KubernetesNodePool) bool) (*fakecloud.KubernetesNodePool, error) {
+	status := func(pool *fakecloud.KubernetesNodePool) string { return pool.Status }
+
+	pool, err := waitForChange(ctx, refresh, status, applied, []string{pending}, []string{kubernetesStatusRunning})
+	if err != nil {
+		return nil, err
+	}
+
+	return pool, nil
+}
+
+// kubernetesNodePoolRefresh returns a function reading a node pool, to wait
+// on.
+func kubernetesNodePoolRefresh(client *apiClient, clusterID int, id int) func(ctx context.Context) (*fakecloud.KubernetesNodePool, error) {
+	return func(ctx context.Context) (*fakecloud.KubernetesNodePool, error) {
+		return client.GetKubernetesNodePool(ctx, clusterID, id)
+	}
+}
+
+// kubernetesNodePoolUpdated returns a function checking that a node pool has
+// the attributes of the update sent to the API. The API may still report the
+// pool running as it was right after the update request.
+func kubernetesNodePoolUpdated(update *fakecloud.KubernetesNodePool) func(*fakecloud.KubernetesNodePool) bool {
+	return func(pool *fakecloud.KubernetesNodePool) bool {
+		return pool.Name == update.Name &&
+			pool.InstanceType == update.InstanceType &&
+			pool.NodeCount == update.NodeCount &&
+			maps.Equal(pool.Labels, update.Labels)
+	}
+}
+
+// setKubernetesNodePool copies the node pool returned by the API into the
+// model.
+func setKubernetesNodePool(ctx context.Context, data *KubernetesNodePoolResourceModel, client *apiClient, pool *fakecloud.KubernetesNodePool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.ID = types.Int64Value(int64(pool.ID))
+	data.ClusterID = types.Int64Value(int64(pool.ClusterID))
+	data.Name = types.StringValue(pool.Name)
+	data.Region = regionValue(client.region)
+	data.Project = projectValue(client.project)
+	data.InstanceType = types.StringValue(pool.InstanceType)
+	data.NodeCount = types.Int64Value(int64(pool.NodeCount))
+
+	// Keep labels null rather than empty when not configured.
+	if len(pool.Labels) > 0 || !data.Labels.IsNull() {
+		data.Labels, diags = types.MapValueFrom(ctx, types.StringType, pool.Labels)
+	}
+
+	return diags
+}
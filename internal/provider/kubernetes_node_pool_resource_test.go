@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+func TestKubernetesNodePoolUpdated(t *testing.T) {
+	update := &fakecloud.KubernetesNodePool{
+		Name:         "workers",
+		InstanceType: "standard.large",
+		NodeCount:    5,
+		Labels:       map[string]string{"tier": "web"},
+	}
+
+	cases := map[string]struct {
+		pool     fakecloud.KubernetesNodePool
+		expected bool
+	}{
+		"updated": {
+			pool:     fakecloud.KubernetesNodePool{Name: "workers", InstanceType: "standard.large", NodeCount: 5, Labels: map[string]string{"tier": "web"}},
+			expected: true,
+		},
+		"node count pending": {
+			pool: fakecloud.KubernetesNodePool{Name: "workers", InstanceType: "standard.large", NodeCount: 3, Labels: map[string]string{"tier": "web"}},
+		},
+		"instance type pending": {
+			pool: fakecloud.KubernetesNodePool{Name: "workers", InstanceType: "standard.small", NodeCount: 5, Labels: map[string]string{"tier": "web"}},
+		},
+		"labels pending": {
+			pool: fakecloud.KubernetesNodePool{Name: "workers", InstanceType: "standard.large", NodeCount: 5},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if actual := kubernetesNodePoolUpdated(update)(&tc.pool); actual != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, actual)
+			}
+		})
+	}
+}
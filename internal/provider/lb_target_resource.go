@@ -0,0 +1,218 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &LBTargetResource{}
+var _ resource.ResourceWithImportState = &LBTargetResource{}
+var _ resource.ResourceWithModifyPlan = &LBTargetResource{}
+
+func NewLBTargetResource() resource.Resource {
+	return &LBTargetResource{}
+}
+
+// LBTargetResource defines the resource implementation.
+type LBTargetResource struct {
+	providerData *providerData
+}
+
+// LBTargetResourceModel describes the resource data model.
+type LBTargetResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	LoadBalancerID types.Int64  `tfsdk:"load_balancer_id"`
+	VMID           types.Int64  `tfsdk:"vm_id"`
+	Region         types.String `tfsdk:"region"`
+	Project        types.String `tfsdk:"project"`
+	HealthStatus   types.String `tfsdk:"health_status"`
+}
+
+func (r *LBTargetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_lb_target"
+}
+
+func (r *LBTargetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Attaches a virtual machine to a `fakecloud_load_balancer`. " +
+			"Import with `<load_balancer_id>/<vm_id>`, prefixed with `<region>/` outside the provider region, or `<project>/<region>/` outside the provider project.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Target identifier, `<load_balancer_id>/<vm_id>`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"load_balancer_id": schema.Int64Attribute{
+				MarkdownDescription: "Identifier of the load balancer. Changing it forces a new target to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"vm_id": schema.Int64Attribute{
+				MarkdownDescription: "Identifier of the VM. Changing it forces a new target to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Region of the load balancer and VM. Defaults to the provider region. Changing it forces a new target to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project of the load balancer and VM. Defaults to the provider project. Changing it forces a new target to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"health_status": schema.StringAttribute{
+				MarkdownDescription: "Health of the target as reported by the listener health checks, e.g. `healthy`, `unhealthy` or `initial`.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *LBTargetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *LBTargetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.planScope(ctx, req.Config, req.State, req.Plan, resp)
+}
+
+func (r *LBTargetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data LBTargetResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	lbID, vmID := int(data.LoadBalancerID.ValueInt64()), int(data.VMID.ValueInt64())
+
+	err := client.AddLBTarget(ctx, lbID, vmID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to attach load balancer target", err.Error())
+		return
+	}
+
+	tflog.Trace(ctx, "attached a load balancer target")
+
+	target, err := client.GetLBTarget(ctx, lbID, vmID)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to read load balancer target, got error: %s", err), err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%d/%d", lbID, vmID))
+	data.Region = regionValue(client.region)
+	data.Project = projectValue(client.project)
+	data.HealthStatus = types.StringValue(target.HealthStatus)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *LBTargetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data LBTargetResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	lbID, vmID := int(data.LoadBalancerID.ValueInt64()), int(data.VMID.ValueInt64())
+
+	target, err := client.GetLBTarget(ctx, lbID, vmID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read load balancer target, got error: %s", err), err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%d/%d", lbID, vmID))
+	data.Region = regionValue(client.region)
+	data.Project = projectValue(client.project)
+	data.HealthStatus = types.StringValue(target.HealthStatus)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *LBTargetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	updateReplaceOnly(ctx, req, resp)
+}
+
+func (r *LBTargetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data LBTargetResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	err := client.RemoveLBTarget(ctx, int(data.LoadBalancerID.ValueInt64()), int(data.VMID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to detach load balancer target, got error: %s", err), err.Error())
+		return
+	}
+}
+
+func (r *LBTargetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importRegionalChild(ctx, req, resp, "load_balancer_id", "vm_id")
+}
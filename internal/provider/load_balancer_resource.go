@@ -0,0 +1,506 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &LoadBalancerResource{}
+var _ resource.ResourceWithImportState = &LoadBalancerResource{}
+var _ resource.ResourceWithModifyPlan = &LoadBalancerResource{}
+
+// Statuses reported by the Fakecloud API for a load balancer.
+const (
+	lbStatusProvisioning = "provisioning"
+	lbStatusUpdating     = "updating"
+	lbStatusActive       = "active"
+)
+
+// Default timeouts of load balancer operations when the configuration does
+// not set them.
+const (
+	defaultLBCreateTimeout = 10 * time.Minute
+	defaultLBUpdateTimeout = 10 * time.Minute
+)
+
+func NewLoadBalancerResource() resource.Resource {
+	return &LoadBalancerResource{}
+}
+
+// LoadBalancerResource defines the resource implementation.
+type LoadBalancerResource struct {
+	providerData *providerData
+}
+
+// LoadBalancerResourceModel describes the resource data model.
+type LoadBalancerResourceModel struct {
+	ID        types.Int64                 `tfsdk:"id"`
+	Name      types.String                `tfsdk:"name"`
+	Region    types.String                `tfsdk:"region"`
+	Project   types.String                `tfsdk:"project"`
+	Address   types.String                `tfsdk:"address"`
+	Status    types.String                `tfsdk:"status"`
+	Listeners []LoadBalancerListenerModel `tfsdk:"listener"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// LoadBalancerListenerModel describes a listener block.
+type LoadBalancerListenerModel struct {
+	Port        types.Int64                   `tfsdk:"port"`
+	Protocol    types.String                  `tfsdk:"protocol"`
+	TargetPort  types.Int64                   `tfsdk:"target_port"`
+	HealthCheck *LoadBalancerHealthCheckModel `tfsdk:"health_check"`
+}
+
+// LoadBalancerHealthCheckModel describes the health_check block of a listener.
+type LoadBalancerHealthCheckModel struct {
+	Protocol           types.String `tfsdk:"protocol"`
+	Path               types.String `tfsdk:"path"`
+	Port               types.Int64  `tfsdk:"port"`
+	IntervalSeconds    types.Int64  `tfsdk:"interval_seconds"`
+	TimeoutSeconds     types.Int64  `tfsdk:"timeout_seconds"`
+	HealthyThreshold   types.Int64  `tfsdk:"healthy_threshold"`
+	UnhealthyThreshold types.Int64  `tfsdk:"unhealthy_threshold"`
+}
+
+func (r *LoadBalancerResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_load_balancer"
+}
+
+func (r *LoadBalancerResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	portValidators := []validator.Int64{
+		int64validator.Between(1, 65535),
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Load balancer distributing traffic over virtual machines attached with `fakecloud_lb_target`. " +
+			"Creating and updating it waits until it is active.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Load balancer identifier",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the load balancer",
+				Required:            true,
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Region the load balancer is created in. Defaults to the provider region. Changing it forces a new load balancer to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project the load balancer belongs to. Defaults to the provider project. Changing it forces a new load balancer to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"address": schema.StringAttribute{
+				MarkdownDescription: "Public IP address of the load balancer.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Status of the load balancer, e.g. `active`.",
+				Computed:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"listener": schema.ListNestedBlock{
+				MarkdownDescription: "Port the load balancer listens on and forwards to its targets. At least one is required.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"port": schema.Int64Attribute{
+							MarkdownDescription: "Port the load balancer listens on.",
+							Required:            true,
+							Validators:          portValidators,
+						},
+						"protocol": schema.StringAttribute{
+							MarkdownDescription: "Protocol of the listener, one of `http`, `https` or `tcp`.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("http", "https", "tcp"),
+							},
+						},
+						"target_port": schema.Int64Attribute{
+							MarkdownDescription: "Port of the targets traffic is forwarded to.",
+							Required:            true,
+							Validators:          portValidators,
+						},
+					},
+					Blocks: map[string]schema.Block{
+						"health_check": schema.SingleNestedBlock{
+							MarkdownDescription: "Health check deciding which targets receive traffic. Targets are checked with TCP on `target_port` when omitted.",
+							Attributes: map[string]schema.Attribute{
+								"protocol": schema.StringAttribute{
+									MarkdownDescription: "Protocol of the check, one of `http`, `https` or `tcp`. Defaults to `tcp`.",
+									Optional:            true,
+									Computed:            true,
+									Default:             stringdefault.StaticString("tcp"),
+									Validators: []validator.String{
+										stringvalidator.OneOf("http", "https", "tcp"),
+									},
+								},
+								"path": schema.StringAttribute{
+									MarkdownDescription: "Path requested by `http` and `https` checks, e.g. `/healthz`.",
+									Optional:            true,
+								},
+								"port": schema.Int64Attribute{
+									MarkdownDescription: "Port checked on the targets. Defaults to the `target_port` of the listener.",
+									Optional:            true,
+									Validators:          portValidators,
+								},
+								"interval_seconds": schema.Int64Attribute{
+									MarkdownDescription: "Seconds between two checks. Defaults to `10`.",
+									Optional:            true,
+									Computed:            true,
+									Default:             int64default.StaticInt64(10),
+									Validators: []validator.Int64{
+										int64validator.Between(5, 300),
+									},
+								},
+								"timeout_seconds": schema.Int64Attribute{
+									MarkdownDescription: "Seconds before a check fails. Defaults to `5`.",
+									Optional:            true,
+									Computed:            true,
+									Default:             int64default.StaticInt64(5),
+									Validators: []validator.Int64{
+										int64validator.Between(1, 60),
+									},
+								},
+								"healthy_threshold": schema.Int64Attribute{
+									MarkdownDescription: "Consecutive successful checks before a target is healthy. Defaults to `3`.",
+									Optional:            true,
+									Computed:            true,
+									Default:             int64default.StaticInt64(3),
+									Validators: []validator.Int64{
+										int64validator.Between(1, 10),
+									},
+								},
+								"unhealthy_threshold": schema.Int64Attribute{
+									MarkdownDescription: "Consecutive failed checks before a target is unhealthy. Defaults to `3`.",
+									Optional:            true,
+									Computed:            true,
+									Default:             int64default.StaticInt64(3),
+									Validators: []validator.Int64{
+										int64validator.Between(1, 10),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+func (r *LoadBalancerResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *LoadBalancerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.planScope(ctx, req.Config, req.State, req.Plan, resp)
+}
+
+func (r *LoadBalancerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data LoadBalancerResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultLBCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	lb, err := client.CreateLoadBalancer(ctx, &fakecloud.LoadBalancer{
+		Name:      data.Name.ValueString(),
+		Listeners: expandListeners(data.Listeners),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to create load balancer", err.Error())
+		return
+	}
+
+	data.ID = types.Int64Value(int64(lb.ID))
+	saveCreated(ctx, resp, client, data.ID)
+
+	tflog.Trace(ctx, "created a load balancer")
+
+	lb, err = r.waitActive(ctx, client, lb.ID, lbStatusProvisioning)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to create load balancer, got error waiting for it to become active: %s", err), err.Error())
+		return
+	}
+
+	r.setLoadBalancer(&data, client, lb)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *LoadBalancerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data LoadBalancerResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	lb, err := client.GetLoadBalancer(ctx, int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read load balancer, got error: %s", err), err.Error())
+		return
+	}
+
+	r.setLoadBalancer(&data, client, lb)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *LoadBalancerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data LoadBalancerResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultLBUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	id := int(data.ID.ValueInt64())
+
+	err := client.UpdateLoadBalancer(ctx, id, data.Name.ValueString(), expandListeners(data.Listeners))
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to update load balancer, got error: %s", err), err.Error())
+		return
+	}
+
+	lb, err := r.waitActive(ctx, client, id, lbStatusUpdating)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to update load balancer, got error waiting for it to become active: %s", err), err.Error())
+		return
+	}
+
+	r.setLoadBalancer(&data, client, lb)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *LoadBalancerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data LoadBalancerResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	err := client.DeleteLoadBalancer(ctx, int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to delete load balancer, got error: %s", err), err.Error())
+		return
+	}
+}
+
+func (r *LoadBalancerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importRegional(ctx, req, resp)
+}
+
+// waitActive waits for the load balancer to leave the pending status and
+// returns it once active.
+func (r *LoadBalancerResource) waitActive(ctx context.Context, client *apiClient, id int, pending string) (*fakecloud.LoadBalancer, error) {
+	var lb *fakecloud.LoadBalancer
+
+	refresh := func(ctx context.Context) (string, error) {
+		var err error
+
+		lb, err = client.GetLoadBalancer(ctx, id)
+		if err != nil {
+			return "", err
+		}
+
+		return lb.Status, nil
+	}
+
+	if _, err := waitForState(ctx, refresh, []string{pending}, []string{lbStatusActive}); err != nil {
+		return nil, err
+	}
+
+	return lb, nil
+}
+
+// setLoadBalancer copies the load balancer returned by the API into the
+// model.
+func (r *LoadBalancerResource) setLoadBalancer(data *LoadBalancerResourceModel, client *apiClient, lb *fakecloud.LoadBalancer) {
+	data.ID = types.Int64Value(int64(lb.ID))
+	data.Name = types.StringValue(lb.Name)
+	data.Region = regionValue(client.region)
+	data.Project = projectValue(client.project)
+	data.Address = types.StringValue(lb.Address)
+	data.Status = types.StringValue(lb.Status)
+	data.Listeners = flattenListeners(lb.Listeners)
+}
+
+// expandListeners converts listener blocks into their API representation.
+func expandListeners(listeners []LoadBalancerListenerModel) []fakecloud.Listener {
+	result := make([]fakecloud.Listener, 0, len(listeners))
+
+	for _, listener := range listeners {
+		l := fakecloud.Listener{
+			Port:       int(listener.Port.ValueInt64()),
+			Protocol:   listener.Protocol.ValueString(),
+			TargetPort: int(listener.TargetPort.ValueInt64()),
+		}
+
+		if hc := listener.HealthCheck; hc != nil {
+			l.HealthCheck = &fakecloud.HealthCheck{
+				Protocol:           hc.Protocol.ValueString(),
+				Path:               hc.Path.ValueString(),
+				Port:               int(hc.Port.ValueInt64()),
+				IntervalSeconds:    int(hc.IntervalSeconds.ValueInt64()),
+				TimeoutSeconds:     int(hc.TimeoutSeconds.ValueInt64()),
+				HealthyThreshold:   int(hc.HealthyThreshold.ValueInt64()),
+				UnhealthyThreshold: int(hc.UnhealthyThreshold.ValueInt64()),
+			}
+		}
+
+		result = append(result, l)
+	}
+
+	return result
+}
+
+// flattenListeners converts listeners returned by the API into listener
+// blocks.
+func flattenListeners(listeners []fakecloud.Listener) []LoadBalancerListenerModel {
+	result := make([]LoadBalancerListenerModel, 0, len(listeners))
+
+	for _, listener := range listeners {
+		l := LoadBalancerListenerModel{
+			Port:       types.Int64Value(int64(listener.Port)),
+			Protocol:   types.StringValue(listener.Protocol),
+			TargetPort: types.Int64Value(int64(listener.TargetPort)),
+		}
+
+		if hc := listener.HealthCheck; hc != nil {
+			l.HealthCheck = &LoadBalancerHealthCheckModel{
+				Protocol:           types.StringValue(hc.Protocol),
+				Path:               types.StringNull(),
+				Port:               types.Int64Null(),
+				IntervalSeconds:    types.Int64Value(int64(hc.IntervalSeconds)),
+				TimeoutSeconds:     types.Int64Value(int64(hc.TimeoutSeconds)),
+				HealthyThreshold:   types.Int64Value(int64(hc.HealthyThreshold)),
+				UnhealthyThreshold: types.Int64Value(int64(hc.UnhealthyThreshold)),
+			}
+
+			if hc.Path != "" {
+				l.HealthCheck.Path = types.StringValue(hc.Path)
+			}
+
+			if hc.Port != 0 {
+				l.HealthCheck.Port = types.Int64Value(int64(hc.Port))
+			}
+		}
+
+		result = append(result, l)
+	}
+
+	return result
+}
@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestListenersRoundTrip(t *testing.T) {
+	listeners := []LoadBalancerListenerModel{
+		{
+			Port:       types.Int64Value(443),
+			Protocol:   types.StringValue("https"),
+			TargetPort: types.Int64Value(8080),
+			HealthCheck: &LoadBalancerHealthCheckModel{
+				Protocol:           types.StringValue("http"),
+				Path:               types.StringValue("/healthz"),
+				Port:               types.Int64Null(),
+				IntervalSeconds:    types.Int64Value(10),
+				TimeoutSeconds:     types.Int64Value(5),
+				HealthyThreshold:   types.Int64Value(3),
+				UnhealthyThreshold: types.Int64Value(2),
+			},
+		},
+		{
+			Port:       types.Int64Value(22),
+			Protocol:   types.StringValue("tcp"),
+			TargetPort: types.Int64Value(22),
+		},
+	}
+
+	got := flattenListeners(expandListeners(listeners))
+
+	if diff := cmp.Diff(listeners, got); diff != "" {
+		t.Errorf("unexpected listeners (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ParseVMIDFunction{}
+
+func NewParseVMIDFunction() function.Function {
+	return &ParseVMIDFunction{}
+}
+
+// ParseVMIDFunction defines the parse_vm_id function implementation.
+type ParseVMIDFunction struct{}
+
+// parseVMIDResultTypes describes the object returned by parse_vm_id.
+var parseVMIDResultTypes = map[string]attr.Type{
+	"project": types.StringType,
+	"region":  types.StringType,
+	"id":      types.Int64Type,
+}
+
+func (f *ParseVMIDFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_vm_id"
+}
+
+func (f *ParseVMIDFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse a virtual machine identifier",
+		MarkdownDescription: "Splits a virtual machine identifier, as accepted by `terraform import`, of the form `<project>/<region>/<id>`, `<region>/<id>` or `<id>` " +
+			"into an object with `project`, `region` and `id` attributes. `project` and `region` are null when the identifier does not include them.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "vm_id",
+				MarkdownDescription: "Virtual machine identifier to parse.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: parseVMIDResultTypes,
+		},
+	}
+}
+
+func (f *ParseVMIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var vmID string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &vmID))
+	if resp.Error != nil {
+		return
+	}
+
+	project, region, id, err := parseImportID(vmID)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0, err.Error()))
+		return
+	}
+
+	result, diags := types.ObjectValue(parseVMIDResultTypes, map[string]attr.Value{
+		"project": projectValue(project),
+		"region":  regionValue(region),
+		"id":      types.Int64Value(id),
+	})
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseVMIDFunctionRun(t *testing.T) {
+	cases := map[string]struct {
+		request  function.RunRequest
+		expected function.RunResponse
+	}{
+		"with project": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("prod/eu-west/42")}),
+			},
+			expected: function.RunResponse{
+				Result: function.NewResultData(types.ObjectValueMust(parseVMIDResultTypes, map[string]attr.Value{
+					"project": types.StringValue("prod"),
+					"region":  types.StringValue("eu-west"),
+					"id":      types.Int64Value(42),
+				})),
+			},
+		},
+		"with region": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("eu-west/42")}),
+			},
+			expected: function.RunResponse{
+				Result: function.NewResultData(types.ObjectValueMust(parseVMIDResultTypes, map[string]attr.Value{
+					"project": types.StringNull(),
+					"region":  types.StringValue("eu-west"),
+					"id":      types.Int64Value(42),
+				})),
+			},
+		},
+		"without region": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("42")}),
+			},
+			expected: function.RunResponse{
+				Result: function.NewResultData(types.ObjectValueMust(parseVMIDResultTypes, map[string]attr.Value{
+					"project": types.StringNull(),
+					"region":  types.StringNull(),
+					"id":      types.Int64Value(42),
+				})),
+			},
+		},
+		"invalid": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("eu-west/vm-42")}),
+			},
+			expected: function.RunResponse{
+				Error:  function.NewArgumentFuncError(0, `expected import identifier with format <project>/<region>/<id>, <region>/<id> or <id>, got "eu-west/vm-42": the identifier must be a number`),
+				Result: function.NewResultData(types.ObjectUnknown(parseVMIDResultTypes)),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := function.RunResponse{
+				Result: function.NewResultData(types.ObjectUnknown(parseVMIDResultTypes)),
+			}
+
+			NewParseVMIDFunction().Run(context.Background(), tc.request, &got)
+
+			if !got.Error.Equal(tc.expected.Error) {
+				t.Errorf("expected error %v, got %v", tc.expected.Error, got.Error)
+			}
+
+			if !got.Result.Equal(tc.expected.Result) {
+				t.Errorf("expected result %s, got %s", tc.expected.Result.Value(), got.Result.Value())
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ProjectResource{}
+var _ resource.ResourceWithImportState = &ProjectResource{}
+
+// projectNameRegexp matches valid project names: 3 to 30 lower case letters,
+// digits and hyphens, starting with a letter and not ending with a hyphen.
+var projectNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9-]{1,28}[a-z0-9]$`)
+
+func NewProjectResource() resource.Resource {
+	return &ProjectResource{}
+}
+
+// ProjectResource defines the resource implementation.
+type ProjectResource struct {
+	providerData *providerData
+}
+
+// ProjectResourceModel describes the resource data model.
+type ProjectResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+}
+
+func (r *ProjectResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project"
+}
+
+func (r *ProjectResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Project grouping resources, e.g. an environment. Resources are created in a project through their `project` " +
+			"attribute or the provider `project`. Projects are global, they are not bound to a region. Import with `<name>`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Project identifier, the same as `name`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the project: 3 to 30 lower case letters, digits and hyphens, starting with a letter. " +
+					"Changing it forces a new project to be created.",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(projectNameRegexp, "must be 3 to 30 lower case letters, digits and hyphens, starting with a letter and not ending with a hyphen"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the project",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *ProjectResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// client returns the API client of the global project API, which is not
+// scoped to the provider project.
+func (r *ProjectResource) client(diags *diag.Diagnostics) *apiClient {
+	client, err := r.providerData.globalClient()
+	if err != nil {
+		diags.AddError("Unable to create Fakecloud API client", err.Error())
+		return nil
+	}
+
+	return client
+}
+
+func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ProjectResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client(&resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	project, err := client.CreateProject(ctx, &fakecloud.Project{
+		Name:        data.Name.ValueString(),
+		Description: data.Description.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to create project", err.Error())
+		return
+	}
+
+	setProject(&data, project)
+
+	tflog.Trace(ctx, "created a project")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ProjectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ProjectResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client(&resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	project, err := client.GetProject(ctx, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read project, got error: %s", err), err.Error())
+		return
+	}
+
+	setProject(&data, project)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ProjectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ProjectResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client(&resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	project, err := client.UpdateProject(ctx, &fakecloud.Project{
+		Name:        data.Name.ValueString(),
+		Description: data.Description.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to update project, got error: %s", err), err.Error())
+		return
+	}
+
+	setProject(&data, project)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ProjectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ProjectResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client(&resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	// The API refuses to delete a project that still holds resources.
+	err := client.DeleteProject(ctx, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to delete project, got error: %s", err), err.Error())
+		return
+	}
+}
+
+func (r *ProjectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if !projectNameRegexp.MatchString(req.ID) {
+		resp.Diagnostics.AddError("Unexpected Import Identifier",
+			fmt.Sprintf("expected the name of the project, got %q", req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), req.ID)...)
+}
+
+// setProject copies the project returned by the API into the model. The
+// description stays null when empty and not configured.
+func setProject(data *ProjectResourceModel, project *fakecloud.Project) {
+	data.ID = types.StringValue(project.Name)
+	data.Name = types.StringValue(project.Name)
+
+	if project.Description != "" || !data.Description.IsNull() {
+		data.Description = types.StringValue(project.Description)
+	}
+}
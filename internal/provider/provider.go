@@ -5,19 +5,24 @@ package provider
 
 import (
 	"context"
+	"net/http"
 	"os"
+	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	fakecloud "github.com/pokgak/fakecloud/sdk"
+	"go.opentelemetry.io/otel"
 )
 
 // Ensure the implementation satisfies various provider interfaces.
 var _ provider.Provider = &FakecloudProvider{}
+var _ provider.ProviderWithFunctions = &FakecloudProvider{}
 
 // FakecloudProvider defines the provider implementation.
 type FakecloudProvider struct {
@@ -32,6 +37,14 @@ type FakecloudProviderModel struct {
 	Host     types.String `tfsdk:"host"`
 	Username types.String `tfsdk:"username"`
 	Password types.String `tfsdk:"password"`
+	Region   types.String `tfsdk:"region"`
+	Regions  types.Map    `tfsdk:"regions"`
+	Project  types.String `tfsdk:"project"`
+
+	MaxRequestsPerSecond  types.Int64 `tfsdk:"max_requests_per_second"`
+	MaxConcurrentRequests types.Int64 `tfsdk:"max_concurrent_requests"`
+
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
 }
 
 func (p *FakecloudProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -43,7 +56,8 @@ func (p *FakecloudProvider) Schema(ctx context.Context, req provider.SchemaReque
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"host": schema.StringAttribute{
-				Optional: true,
+				MarkdownDescription: "Fakecloud API endpoint. May contain a `{region}` placeholder, replaced by the region a resource lives in.",
+				Optional:            true,
 			},
 			"username": schema.StringAttribute{
 				Optional: true,
@@ -52,6 +66,36 @@ func (p *FakecloudProvider) Schema(ctx context.Context, req provider.SchemaReque
 				Optional:  true,
 				Sensitive: true,
 			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Region used by resources and data sources that do not set their own `region`. " +
+					"May also be provided via the `FAKECLOUD_REGION` environment variable.",
+				Optional: true,
+			},
+			"regions": schema.MapAttribute{
+				MarkdownDescription: "Fakecloud API endpoint of each region, keyed by region name. Takes precedence over `host`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project used by resources and data sources that do not set their own `project`. Every API call is scoped to a project. " +
+					"May also be provided via the `FAKECLOUD_PROJECT` environment variable. The default project of the account is used when unset.",
+				Optional: true,
+			},
+			"max_requests_per_second": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of Fakecloud API requests per second made by all resources and data sources of this provider instance. " +
+					"May also be provided via the `FAKECLOUD_MAX_REQUESTS_PER_SECOND` environment variable. Unlimited when unset or `0`.",
+				Optional: true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of Fakecloud API requests in flight at once across all resources and data sources of this provider instance. " +
+					"May also be provided via the `FAKECLOUD_MAX_CONCURRENT_REQUESTS` environment variable. Unlimited when unset or `0`.",
+				Optional: true,
+			},
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: "Default `deletion_protection` of resources that do not set it. " +
+					"May also be provided via the `FAKECLOUD_DELETION_PROTECTION` environment variable. Defaults to `false`.",
+				Optional: true,
+			},
 		},
 	}
 }
@@ -77,6 +121,42 @@ func (p *FakecloudProvider) Configure(ctx context.Context, req provider.Configur
 		)
 	}
 
+	if config.Region.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("region"),
+			"Unknown Fakecloud Region",
+			"The provider cannot create the Fakecloud API client as there is an unknown configuration value for the Fakecloud region. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the FAKECLOUD_REGION environment variable.",
+		)
+	}
+
+	if config.Regions.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("regions"),
+			"Unknown Fakecloud Region Endpoints",
+			"The provider cannot create the Fakecloud API client as there is an unknown configuration value for the Fakecloud region endpoints. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.Project.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("project"),
+			"Unknown Fakecloud Project",
+			"The provider cannot create the Fakecloud API client as there is an unknown configuration value for the Fakecloud project. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the FAKECLOUD_PROJECT environment variable.",
+		)
+	}
+
+	if config.DeletionProtection.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("deletion_protection"),
+			"Unknown Fakecloud Deletion Protection Default",
+			"The provider cannot be configured as there is an unknown configuration value for the default deletion protection. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the FAKECLOUD_DELETION_PROTECTION environment variable.",
+		)
+	}
+
 	// if config.Username.IsUnknown() {
 	// 	resp.Diagnostics.AddAttributeError(
 	// 		path.Root("username"),
@@ -105,6 +185,8 @@ func (p *FakecloudProvider) Configure(ctx context.Context, req provider.Configur
 	host := os.Getenv("FAKECLOUD_HOST")
 	username := os.Getenv("FAKECLOUD_USERNAME")
 	password := os.Getenv("FAKECLOUD_PASSWORD")
+	region := os.Getenv("FAKECLOUD_REGION")
+	project := os.Getenv("FAKECLOUD_PROJECT")
 
 	if !config.Host.IsNull() {
 		host = config.Host.ValueString()
@@ -118,15 +200,33 @@ func (p *FakecloudProvider) Configure(ctx context.Context, req provider.Configur
 		password = config.Password.ValueString()
 	}
 
+	if !config.Region.IsNull() {
+		region = config.Region.ValueString()
+	}
+
+	if !config.Project.IsNull() {
+		project = config.Project.ValueString()
+	}
+
+	regions := map[string]string{}
+	resp.Diagnostics.Append(config.Regions.ElementsAs(ctx, &regions, false)...)
+
+	deletionProtection := boolSetting(config.DeletionProtection, "FAKECLOUD_DELETION_PROTECTION", path.Root("deletion_protection"), resp)
+
+	var limits apiLimits
+
+	limits.RequestsPerSecond = int64Setting(config.MaxRequestsPerSecond, "FAKECLOUD_MAX_REQUESTS_PER_SECOND", path.Root("max_requests_per_second"), resp)
+	limits.ConcurrentRequests = int64Setting(config.MaxConcurrentRequests, "FAKECLOUD_MAX_CONCURRENT_REQUESTS", path.Root("max_concurrent_requests"), resp)
+
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
 
-	if host == "" {
+	if host == "" && len(regions) == 0 {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("host"),
 			"Missing Fakecloud API Host",
 			"The provider cannot create the Fakecloud API client as there is a missing or empty value for the Fakecloud API host. "+
-				"Set the host value in the configuration or use the FAKECLOUD_HOST environment variable. "+
+				"Set the host or regions value in the configuration or use the FAKECLOUD_HOST environment variable. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
@@ -155,27 +255,175 @@ func (p *FakecloudProvider) Configure(ctx context.Context, req provider.Configur
 		return
 	}
 
-	// Create a new Fakecloud client using the configuration values
-	client, err := fakecloud.NewClient(host, username, password)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Create Fakecloud API Client",
-			"An unexpected error occurred when creating the Fakecloud API client. "+
-				"If the error is not clear, please contact the provider developers.\n\n"+
+	// Clients are created per project and region as resources need them. All
+	// of them share the tracer and the request limits of this provider
+	// instance.
+	data := newProviderData(region, project, host, regions, username, password, newAPIClient(nil, otel.Tracer(tracerName), limits))
+	data.deletionProtection = deletionProtection
+
+	// Create a client up front so that an invalid configuration is reported
+	// against the provider, and check the credentials with it rather than
+	// failing on the first resource or data source call.
+	checkRegion, ok := data.credentialsRegion()
+	if !ok {
+		resp.Diagnostics.AddWarning(
+			"Fakecloud Credentials Not Checked",
+			"The provider has no region to check its credentials in as no region is set and the host is templated on the region. "+
+				"Invalid credentials are reported by the first resource or data source call instead.",
+		)
+	} else {
+		client, err := data.client(project, checkRegion)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Create Fakecloud API Client",
+				"An unexpected error occurred when creating the Fakecloud API client. "+
+					"If the error is not clear, please contact the provider developers.\n\n"+
+					"Fakecloud Client Error: "+err.Error(),
+			)
+			return
+		}
+
+		checkCredentials(ctx, client, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Make the Fakecloud clients available during DataSource and Resource
+	// type Configure methods.
+	resp.DataSourceData = data
+	resp.ResourceData = data
+}
+
+// checkCredentials checks that client authenticates to the Fakecloud API.
+// Rejected credentials and an unreachable API are reported apart.
+func checkCredentials(ctx context.Context, client callerIdentityClient, diags *diag.Diagnostics) {
+	_, err := client.GetCallerIdentity(ctx)
+	if err == nil {
+		return
+	}
+
+	if code, ok := httpStatusCode(err); ok && (code == http.StatusUnauthorized || code == http.StatusForbidden) {
+		diags.AddError(
+			"Unable to Authenticate to Fakecloud",
+			"The provider could not authenticate to the Fakecloud API with the configured credentials. "+
+				"Check the username and password values in the configuration or the FAKECLOUD_USERNAME and FAKECLOUD_PASSWORD environment variables.\n\n"+
 				"Fakecloud Client Error: "+err.Error(),
 		)
 		return
 	}
 
-	// Make the Fakecloud client available during DataSource and Resource
-	// type Configure methods.
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	diags.AddError(
+		"Unable to Connect to Fakecloud",
+		"The provider could not check its credentials as the Fakecloud API did not answer successfully. "+
+			"Check the host and regions values in the configuration or the FAKECLOUD_HOST environment variable.\n\n"+
+			"Fakecloud Client Error: "+err.Error(),
+	)
+}
+
+// int64Setting returns the value of a non-negative numeric provider setting,
+// read from the configuration or else from the named environment variable.
+// Invalid values are reported against the attribute and read as 0.
+func int64Setting(value types.Int64, envVar string, attr path.Path, resp *provider.ConfigureResponse) int64 {
+	if value.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			attr,
+			"Unknown Fakecloud Provider Setting",
+			"The provider cannot be configured as there is an unknown configuration value for "+attr.String()+". "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the "+envVar+" environment variable.",
+		)
+		return 0
+	}
+
+	setting := value.ValueInt64()
+
+	if value.IsNull() {
+		env := os.Getenv(envVar)
+		if env == "" {
+			return 0
+		}
+
+		parsed, err := strconv.ParseInt(env, 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				attr,
+				"Invalid Fakecloud Provider Setting",
+				"The "+envVar+" environment variable must be a whole number: "+err.Error(),
+			)
+			return 0
+		}
+
+		setting = parsed
+	}
+
+	if setting < 0 {
+		resp.Diagnostics.AddAttributeError(
+			attr,
+			"Invalid Fakecloud Provider Setting",
+			"The value for "+attr.String()+" must not be negative. Use 0 to disable the limit.",
+		)
+		return 0
+	}
+
+	return setting
+}
+
+// boolSetting returns a boolean provider setting, read from envVar when not
+// configured, false when neither is set.
+func boolSetting(value types.Bool, envVar string, attr path.Path, resp *provider.ConfigureResponse) bool {
+	if !value.IsNull() {
+		return value.ValueBool()
+	}
+
+	env := os.Getenv(envVar)
+	if env == "" {
+		return false
+	}
+
+	parsed, err := strconv.ParseBool(env)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			attr,
+			"Invalid Fakecloud Provider Setting",
+			"The "+envVar+" environment variable must be a boolean: "+err.Error(),
+		)
+		return false
+	}
+
+	return parsed
 }
 
 func (p *FakecloudProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewVirtualMachineResource,
+		NewSSHKeyResource,
+		NewVMSnapshotResource,
+		NewImageFromSnapshotResource,
+		NewFloatingIPResource,
+		NewFloatingIPAssociationResource,
+		NewLoadBalancerResource,
+		NewLBTargetResource,
+		NewDNSZoneResource,
+		NewDNSRecordResource,
+		NewBucketResource,
+		NewBucketObjectResource,
+		NewIAMUserResource,
+		NewIAMRoleResource,
+		NewIAMPolicyResource,
+		NewIAMPolicyAttachmentResource,
+		NewInstanceGroupResource,
+		NewAutoscalingPolicyResource,
+		NewProjectResource,
+		NewKubernetesClusterResource,
+		NewKubernetesNodePoolResource,
+		NewDatabaseInstanceResource,
+		NewDatabaseUserResource,
+		NewSecretResource,
+		NewSecretVersionResource,
+		NewFirewallPolicyResource,
+		NewFirewallPolicyAttachmentResource,
+		NewBackupPolicyResource,
+		NewBackupPolicyAttachmentResource,
 	}
 }
 
@@ -183,6 +431,22 @@ func (p *FakecloudProvider) DataSources(ctx context.Context) []func() datasource
 	return []func() datasource.DataSource{
 		NewVirtualMachinesDataSource,
 		NewVirtualMachineDataSource,
+		NewRegionsDataSource,
+		NewZonesDataSource,
+		NewImageDataSource,
+		NewDNSZoneDataSource,
+		NewIAMPolicyDocumentDataSource,
+		NewCallerIdentityDataSource,
+		NewSecretVersionDataSource,
+		NewBackupsDataSource,
+	}
+}
+
+func (p *FakecloudProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewParseVMIDFunction,
+		NewInstanceTypeInfoFunction,
+		NewCIDRSubnetsForZonesFunction,
 	}
 }
 
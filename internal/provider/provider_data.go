@@ -0,0 +1,264 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+// regionPlaceholder is replaced by the region name in a templated host.
+const regionPlaceholder = "{region}"
+
+// providerData is made available to the resources and data sources of a
+// configured provider instance. It hands out one API client per project and
+// region, all of them sharing the instrumentation and request limits of the
+// provider.
+type providerData struct {
+	// region is the provider default region, empty when not configured.
+	region string
+
+	// project is the provider default project, empty when not configured in
+	// which case the API uses the default project of the account.
+	project string
+
+	// deletionProtection is the default deletion_protection of resources.
+	deletionProtection bool
+
+	host     string
+	regions  map[string]string
+	username string
+	password string
+
+	base *apiClient
+
+	mu      sync.Mutex
+	clients map[clientScope]*apiClient
+}
+
+// clientScope identifies the API client of a project and region.
+type clientScope struct {
+	project string
+	region  string
+}
+
+func newProviderData(region string, project string, host string, regions map[string]string, username string, password string, base *apiClient) *providerData {
+	return &providerData{
+		region:   region,
+		project:  project,
+		host:     host,
+		regions:  regions,
+		username: username,
+		password: password,
+		base:     base,
+		clients:  map[clientScope]*apiClient{},
+	}
+}
+
+// endpoint resolves the Fakecloud API endpoint serving region.
+func (d *providerData) endpoint(region string) (string, error) {
+	if endpoint, ok := d.regions[region]; ok {
+		return endpoint, nil
+	}
+
+	if strings.Contains(d.host, regionPlaceholder) {
+		if region == "" {
+			return "", fmt.Errorf("no region set and the host %q is templated on the region: set region on the provider or on the resource", d.host)
+		}
+
+		return strings.ReplaceAll(d.host, regionPlaceholder, region), nil
+	}
+
+	if d.host != "" && region == d.region {
+		return d.host, nil
+	}
+
+	if region == "" {
+		return "", fmt.Errorf("no region set: set region on the provider or on the resource")
+	}
+
+	return "", fmt.Errorf("no endpoint configured for region %q: add it to the provider regions map or use a %s placeholder in host", region, regionPlaceholder)
+}
+
+// credentialsRegion returns the region to check the credentials in: the
+// default region, or else the first region of the regions map. There is none
+// when the host is templated on the region and no region is set.
+func (d *providerData) credentialsRegion() (string, bool) {
+	if _, err := d.endpoint(d.region); err == nil {
+		return d.region, true
+	}
+
+	regions := slices.Sorted(maps.Keys(d.regions))
+	if len(regions) == 0 {
+		return "", false
+	}
+
+	return regions[0], true
+}
+
+// client returns the API client for project and region, falling back to the
+// provider default project and region when they are empty. Every call made
+// through the client is scoped to the project.
+func (d *providerData) client(project string, region string) (*apiClient, error) {
+	return d.scopedClient(clientScope{
+		project: d.resolveProject(project),
+		region:  d.resolveRegion(region),
+	})
+}
+
+// globalClient returns an API client for the global APIs, such as projects,
+// which is not scoped to any project. It talks to the region the credentials
+// are checked in.
+func (d *providerData) globalClient() (*apiClient, error) {
+	region, ok := d.credentialsRegion()
+	if !ok {
+		region = d.region
+	}
+
+	return d.scopedClient(clientScope{region: region})
+}
+
+// scopedClient returns the API client of scope, creating it on first use.
+func (d *providerData) scopedClient(scope clientScope) (*apiClient, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if c, ok := d.clients[scope]; ok {
+		return c, nil
+	}
+
+	region := scope.region
+
+	endpoint, err := d.endpoint(region)
+	if err != nil {
+		return nil, err
+	}
+
+	sdk, err := fakecloud.NewClient(endpoint, d.username, d.password)
+	if err != nil {
+		return nil, err
+	}
+
+	if scope.project != "" {
+		sdk = sdk.WithProject(scope.project)
+	}
+
+	c := d.base.forScope(scope.project, region, sdk)
+	d.clients[scope] = c
+
+	return c, nil
+}
+
+// resolveRegion returns region, or the provider default region when empty.
+func (d *providerData) resolveRegion(region string) string {
+	if region == "" {
+		return d.region
+	}
+
+	return region
+}
+
+// resolveProject returns project, or the provider default project when empty.
+func (d *providerData) resolveProject(project string) string {
+	if project == "" {
+		return d.project
+	}
+
+	return project
+}
+
+// regionValue converts a resolved region into its Terraform value, null when
+// no region is in use.
+func regionValue(region string) types.String {
+	if region == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(region)
+}
+
+// projectValue converts a resolved project into its Terraform value, null
+// when the default project of the account is in use.
+func projectValue(project string) types.String {
+	if project == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(project)
+}
+
+// planScope plans the project and, for regional resources, the region of a
+// resource. A resource without a configured project or region follows the
+// provider one, so a change of the provider settings moves it: the existing
+// resource is replaced. Nothing is planned when the resource is destroyed or
+// the provider is not configured yet.
+func (d *providerData) planScope(ctx context.Context, config tfsdk.Config, state tfsdk.State, plan tfsdk.Plan, resp *resource.ModifyPlanResponse) {
+	if d == nil || plan.Raw.IsNull() {
+		return
+	}
+
+	scopes := []struct {
+		name  string
+		value types.String
+	}{
+		{name: "region", value: regionValue(d.region)},
+		{name: "project", value: projectValue(d.project)},
+	}
+
+	for _, scope := range scopes {
+		// Global resources have no region.
+		if _, ok := plan.Schema.GetAttributes()[scope.name]; !ok {
+			continue
+		}
+
+		attr := path.Root(scope.name)
+
+		var configured, prior types.String
+
+		resp.Diagnostics.Append(config.GetAttribute(ctx, attr, &configured)...)
+
+		if !state.Raw.IsNull() {
+			resp.Diagnostics.Append(state.GetAttribute(ctx, attr, &prior)...)
+		}
+
+		if resp.Diagnostics.HasError() || !configured.IsNull() {
+			continue
+		}
+
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, attr, scope.value)...)
+
+		if !state.Raw.IsNull() && !prior.Equal(scope.value) {
+			resp.RequiresReplace = append(resp.RequiresReplace, attr)
+		}
+	}
+}
+
+// clientFor returns the API client for the project and region of a resource,
+// reporting a failure to create it against the region. Global resources pass
+// a null region and are served by the provider region.
+func (d *providerData) clientFor(project types.String, region types.String, diags *diag.Diagnostics) *apiClient {
+	client, err := d.client(project.ValueString(), region.ValueString())
+	if err == nil {
+		return client
+	}
+
+	if region.IsNull() {
+		diags.AddError("Unable to create Fakecloud API client", err.Error())
+	} else {
+		diags.AddAttributeError(path.Root("region"), "Unable to create Fakecloud API client", err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,536 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestProviderDataEndpoint(t *testing.T) {
+	cases := map[string]struct {
+		data     *providerData
+		region   string
+		expected string
+		err      bool
+	}{
+		"single host": {
+			data:     &providerData{host: "https://api.fakecloud.test"},
+			expected: "https://api.fakecloud.test",
+		},
+		"single host default region": {
+			data:     &providerData{host: "https://api.fakecloud.test", region: "eu-west"},
+			region:   "eu-west",
+			expected: "https://api.fakecloud.test",
+		},
+		"single host other region": {
+			data:   &providerData{host: "https://api.fakecloud.test", region: "eu-west"},
+			region: "us-east",
+			err:    true,
+		},
+		"templated host": {
+			data:     &providerData{host: "https://{region}.fakecloud.test"},
+			region:   "us-east",
+			expected: "https://us-east.fakecloud.test",
+		},
+		"templated host without region": {
+			data: &providerData{host: "https://{region}.fakecloud.test"},
+			err:  true,
+		},
+		"regions map takes precedence": {
+			data: &providerData{
+				host:    "https://{region}.fakecloud.test",
+				regions: map[string]string{"us-east": "https://legacy.fakecloud.test"},
+			},
+			region:   "us-east",
+			expected: "https://legacy.fakecloud.test",
+		},
+		"regions map unknown region": {
+			data:   &providerData{regions: map[string]string{"us-east": "https://us.fakecloud.test"}},
+			region: "eu-west",
+			err:    true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			endpoint, err := tc.data.endpoint(tc.region)
+
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected an error, got endpoint %q", endpoint)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if endpoint != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, endpoint)
+			}
+		})
+	}
+}
+
+func TestProviderDataCredentialsRegion(t *testing.T) {
+	cases := map[string]struct {
+		data     *providerData
+		expected string
+		ok       bool
+	}{
+		"single host": {
+			data: &providerData{host: "https://api.fakecloud.test"},
+			ok:   true,
+		},
+		"default region": {
+			data:     &providerData{host: "https://{region}.fakecloud.test", region: "eu-west"},
+			expected: "eu-west",
+			ok:       true,
+		},
+		"regions map without default region": {
+			data: &providerData{regions: map[string]string{
+				"us-east": "https://us.fakecloud.test",
+				"eu-west": "https://eu.fakecloud.test",
+			}},
+			expected: "eu-west",
+			ok:       true,
+		},
+		"templated host without region": {
+			data: &providerData{host: "https://{region}.fakecloud.test"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			region, ok := tc.data.credentialsRegion()
+
+			if region != tc.expected || ok != tc.ok {
+				t.Errorf("expected %q and %t, got %q and %t", tc.expected, tc.ok, region, ok)
+			}
+		})
+	}
+}
+
+func TestProviderDataGlobalClient(t *testing.T) {
+	cases := map[string]struct {
+		data   *providerData
+		region string
+		err    bool
+	}{
+		"default project": {
+			data:   newProviderData("eu-west", "acme", "https://{region}.fakecloud.test", nil, "", "", newAPIClient(nil, nil, apiLimits{})),
+			region: "eu-west",
+		},
+		"regions map without default region": {
+			data: newProviderData("", "acme", "", map[string]string{
+				"us-east": "https://us.fakecloud.test",
+				"eu-west": "https://eu.fakecloud.test",
+			}, "", "", newAPIClient(nil, nil, apiLimits{})),
+			region: "eu-west",
+		},
+		"templated host without region": {
+			data: newProviderData("", "acme", "https://{region}.fakecloud.test", nil, "", "", newAPIClient(nil, nil, apiLimits{})),
+			err:  true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			client, err := tc.data.globalClient()
+			if tc.err {
+				if err == nil {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if client.project != "" || client.region != tc.region {
+				t.Errorf("expected an unscoped client of region %q, got project %q and region %q", tc.region, client.project, client.region)
+			}
+
+			// Resources of the default project keep their own client.
+			if scoped, err := tc.data.client("", tc.region); err != nil || scoped.project != "acme" {
+				t.Errorf("expected the client of project acme, got %v (%v)", scoped, err)
+			}
+		})
+	}
+}
+
+func TestProviderDataPlanScope(t *testing.T) {
+	data := &providerData{region: "eu-central", project: "acme"}
+
+	cases := map[string]struct {
+		resource resource.Resource
+		config   map[string]attr.Value
+		// state is the prior resource, nil when the resource is created.
+		state    map[string]attr.Value
+		expected map[string]attr.Value
+		replace  path.Paths
+	}{
+		"create": {
+			resource: &VMSnapshotResource{},
+			expected: map[string]attr.Value{"region": types.StringValue("eu-central"), "project": types.StringValue("acme")},
+		},
+		"create configured": {
+			resource: &VMSnapshotResource{},
+			config:   map[string]attr.Value{"region": types.StringValue("us-east"), "project": types.StringValue("other")},
+			expected: map[string]attr.Value{"region": types.StringValue("us-east"), "project": types.StringValue("other")},
+		},
+		"provider region changed": {
+			resource: &VMSnapshotResource{},
+			state:    map[string]attr.Value{"region": types.StringValue("us-east"), "project": types.StringValue("acme")},
+			expected: map[string]attr.Value{"region": types.StringValue("eu-central"), "project": types.StringValue("acme")},
+			replace:  path.Paths{path.Root("region")},
+		},
+		"provider project changed": {
+			resource: &VMSnapshotResource{},
+			state:    map[string]attr.Value{"region": types.StringValue("eu-central"), "project": types.StringValue("other")},
+			expected: map[string]attr.Value{"region": types.StringValue("eu-central"), "project": types.StringValue("acme")},
+			replace:  path.Paths{path.Root("project")},
+		},
+		"configured scope kept": {
+			resource: &VMSnapshotResource{},
+			config:   map[string]attr.Value{"region": types.StringValue("us-east")},
+			state:    map[string]attr.Value{"region": types.StringValue("us-east"), "project": types.StringValue("acme")},
+			expected: map[string]attr.Value{"region": types.StringValue("us-east"), "project": types.StringValue("acme")},
+		},
+		"global": {
+			resource: &DNSZoneResource{},
+			state:    map[string]attr.Value{"project": types.StringNull()},
+			expected: map[string]attr.Value{"project": types.StringValue("acme")},
+			replace:  path.Paths{path.Root("project")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			// Only the scope is configured, the other attributes are null
+			// alike in the configuration and the plan.
+			config := testResourceState(t, tc.resource, tc.config)
+			if config.Raw.IsNull() {
+				config = testResourceState(t, tc.resource, map[string]attr.Value{"project": types.StringNull()})
+			}
+
+			state := testResourceState(t, tc.resource, tc.state)
+			plan := tfsdk.Plan{Schema: config.Schema, Raw: config.Raw}
+			resp := resource.ModifyPlanResponse{Plan: plan}
+
+			data.planScope(ctx, tfsdk.Config{Schema: config.Schema, Raw: config.Raw}, state, plan, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			actual := map[string]attr.Value{}
+			for name := range tc.expected {
+				var value types.String
+				resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root(name), &value)...)
+				actual[name] = value
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("unexpected scope (-expected +actual):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.replace, resp.RequiresReplace); diff != "" {
+				t.Errorf("unexpected replacements (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseImportID(t *testing.T) {
+	cases := map[string]struct {
+		project string
+		region  string
+		id      int64
+		err     bool
+	}{
+		"42":              {id: 42},
+		"eu-west/42":      {region: "eu-west", id: 42},
+		"prod/eu-west/42": {project: "prod", region: "eu-west", id: 42},
+		"prod//42":        {project: "prod", id: 42},
+		"/42":             {err: true},
+		"/eu-west/42":     {err: true},
+		"eu-west/vm":      {err: true},
+		"a/b/c/42":        {err: true},
+		"":                {err: true},
+	}
+
+	for importID, tc := range cases {
+		t.Run(importID, func(t *testing.T) {
+			project, region, id, err := parseImportID(importID)
+
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected an error, got %q %q %d", project, region, id)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if project != tc.project || region != tc.region || id != tc.id {
+				t.Errorf("expected %q %q %d, got %q %q %d", tc.project, tc.region, tc.id, project, region, id)
+			}
+		})
+	}
+}
+
+func TestParseChildImportID(t *testing.T) {
+	cases := map[string]struct {
+		project  string
+		region   string
+		parentID int64
+		id       int64
+		err      bool
+	}{
+		"7/42":              {parentID: 7, id: 42},
+		"eu-west/7/42":      {region: "eu-west", parentID: 7, id: 42},
+		"prod/eu-west/7/42": {project: "prod", region: "eu-west", parentID: 7, id: 42},
+		"prod//7/42":        {project: "prod", parentID: 7, id: 42},
+		"/7/42":             {err: true},
+		"42":                {err: true},
+		"eu-west/42":        {err: true},
+		"7/vm":              {err: true},
+		"a/b/c/7/42":        {err: true},
+	}
+
+	for importID, tc := range cases {
+		t.Run(importID, func(t *testing.T) {
+			project, region, parentID, id, err := parseChildImportID(importID)
+
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected an error, got %q %q %d %d", project, region, parentID, id)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if project != tc.project || region != tc.region || parentID != tc.parentID || id != tc.id {
+				t.Errorf("expected %q %q %d %d, got %q %q %d %d", tc.project, tc.region, tc.parentID, tc.id, project, region, parentID, id)
+			}
+		})
+	}
+}
+
+func TestParseGlobalImportID(t *testing.T) {
+	cases := map[string]struct {
+		project string
+		id      int64
+		err     bool
+	}{
+		"42":        {id: 42},
+		"prod/42":   {project: "prod", id: 42},
+		"/42":       {err: true},
+		"prod/zone": {err: true},
+		"a/b/42":    {err: true},
+		"":          {err: true},
+	}
+
+	for importID, tc := range cases {
+		t.Run(importID, func(t *testing.T) {
+			project, id, err := parseGlobalImportID(importID)
+
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected an error, got %q %d", project, id)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if project != tc.project || id != tc.id {
+				t.Errorf("expected %q %d, got %q %d", tc.project, tc.id, project, id)
+			}
+		})
+	}
+}
+
+func TestParseGlobalChildImportID(t *testing.T) {
+	cases := map[string]struct {
+		project  string
+		parentID int64
+		id       int64
+		err      bool
+	}{
+		"7/42":      {parentID: 7, id: 42},
+		"prod/7/42": {project: "prod", parentID: 7, id: 42},
+		"/7/42":     {err: true},
+		"42":        {err: true},
+		"7/record":  {err: true},
+		"a/b/7/42":  {err: true},
+	}
+
+	for importID, tc := range cases {
+		t.Run(importID, func(t *testing.T) {
+			project, parentID, id, err := parseGlobalChildImportID(importID)
+
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected an error, got %q %d %d", project, parentID, id)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if project != tc.project || parentID != tc.parentID || id != tc.id {
+				t.Errorf("expected %q %d %d, got %q %d %d", tc.project, tc.parentID, tc.id, project, parentID, id)
+			}
+		})
+	}
+}
+
+func TestParseBucketImportID(t *testing.T) {
+	cases := map[string]struct {
+		project string
+		region  string
+		name    string
+		err     bool
+	}{
+		"artifacts":              {name: "artifacts"},
+		"eu-west/artifacts":      {region: "eu-west", name: "artifacts"},
+		"prod/eu-west/artifacts": {project: "prod", region: "eu-west", name: "artifacts"},
+		"/artifacts":             {err: true},
+		"eu-west/":               {err: true},
+		"":                       {err: true},
+		"a/b/c/artifacts":        {err: true},
+	}
+
+	for importID, tc := range cases {
+		t.Run(importID, func(t *testing.T) {
+			project, region, name, err := parseBucketImportID(importID)
+
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected an error, got %q %q %q", project, region, name)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if project != tc.project || region != tc.region || name != tc.name {
+				t.Errorf("expected %q %q %q, got %q %q %q", tc.project, tc.region, tc.name, project, region, name)
+			}
+		})
+	}
+}
+
+func TestParseBucketObjectImportID(t *testing.T) {
+	cases := map[string]struct {
+		project string
+		region  string
+		bucket  string
+		key     string
+		err     bool
+	}{
+		"artifacts/boot.sh":                 {bucket: "artifacts", key: "boot.sh"},
+		"artifacts/vm/bootstrap/boot.sh":    {bucket: "artifacts", key: "vm/bootstrap/boot.sh"},
+		"eu-west:artifacts/vm/boot.sh":      {region: "eu-west", bucket: "artifacts", key: "vm/boot.sh"},
+		"prod:eu-west:artifacts/vm/boot.sh": {project: "prod", region: "eu-west", bucket: "artifacts", key: "vm/boot.sh"},
+		"artifacts/vm:boot.sh":              {bucket: "artifacts", key: "vm:boot.sh"},
+		":artifacts/boot.sh":                {err: true},
+		"artifacts":                         {err: true},
+		"artifacts/":                        {err: true},
+		"eu-west:artifacts":                 {err: true},
+		"/boot.sh":                          {err: true},
+		"a:b:c:artifacts/boot.sh":           {err: true},
+	}
+
+	for importID, tc := range cases {
+		t.Run(importID, func(t *testing.T) {
+			project, region, bucket, key, err := parseBucketObjectImportID(importID)
+
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected an error, got %q %q %q %q", project, region, bucket, key)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if project != tc.project || region != tc.region || bucket != tc.bucket || key != tc.key {
+				t.Errorf("expected %q %q %q %q, got %q %q %q %q", tc.project, tc.region, tc.bucket, tc.key, project, region, bucket, key)
+			}
+		})
+	}
+}
+
+func TestParseIAMPolicyAttachmentID(t *testing.T) {
+	cases := map[string]struct {
+		project       string
+		policyID      int64
+		principalType string
+		principalID   int64
+		err           bool
+	}{
+		"3/user/7":      {policyID: 3, principalType: "user", principalID: 7},
+		"3/role/12":     {policyID: 3, principalType: "role", principalID: 12},
+		"prod/3/user/7": {project: "prod", policyID: 3, principalType: "user", principalID: 7},
+		"3/group/7":     {err: true},
+		"3/7":           {err: true},
+		"p/user/7":      {err: true},
+		"3/user/u":      {err: true},
+		"/3/user/7":     {err: true},
+		"a/b/3/user/7":  {err: true},
+	}
+
+	for importID, tc := range cases {
+		t.Run(importID, func(t *testing.T) {
+			project, policyID, principalType, principalID, err := parseIAMPolicyAttachmentID(importID)
+
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected an error, got %q %d %q %d", project, policyID, principalType, principalID)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if project != tc.project || policyID != tc.policyID || principalType != tc.principalType || principalID != tc.principalID {
+				t.Errorf("expected %q %d %q %d, got %q %d %q %d", tc.project, tc.policyID, tc.principalType, tc.principalID, project, policyID, principalType, principalID)
+			}
+		})
+	}
+}
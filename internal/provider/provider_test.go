@@ -4,10 +4,22 @@
 package provider
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -23,3 +35,208 @@ func testAccPreCheck(t *testing.T) {
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
 }
+
+func TestBoolSetting(t *testing.T) {
+	cases := map[string]struct {
+		value    types.Bool
+		env      string
+		expected bool
+		err      bool
+	}{
+		"unset": {
+			value: types.BoolNull(),
+		},
+		"configured": {
+			value:    types.BoolValue(true),
+			expected: true,
+		},
+		"configured over environment": {
+			value: types.BoolValue(false),
+			env:   "true",
+		},
+		"environment": {
+			value:    types.BoolNull(),
+			env:      "true",
+			expected: true,
+		},
+		"environment number": {
+			value:    types.BoolNull(),
+			env:      "1",
+			expected: true,
+		},
+		"environment false": {
+			value: types.BoolNull(),
+			env:   "false",
+		},
+		"invalid environment": {
+			value: types.BoolNull(),
+			env:   "yes",
+			err:   true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("FAKECLOUD_DELETION_PROTECTION", tc.env)
+
+			var resp provider.ConfigureResponse
+
+			got := boolSetting(tc.value, "FAKECLOUD_DELETION_PROTECTION", path.Root("deletion_protection"), &resp)
+
+			if resp.Diagnostics.HasError() != tc.err {
+				t.Fatalf("expected error %t, got %v", tc.err, resp.Diagnostics)
+			}
+
+			if got != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestCheckCredentials(t *testing.T) {
+	cases := map[string]struct {
+		err      error
+		expected string
+	}{
+		"authenticated": {},
+		"unauthorized": {
+			err:      errors.New("unexpected status code: 401"),
+			expected: "Unable to Authenticate to Fakecloud",
+		},
+		"forbidden": {
+			err:      fmt.Errorf("get caller identity: %w", errors.New("unexpected status code: 403")),
+			expected: "Unable to Authenticate to Fakecloud",
+		},
+		"server error": {
+			err:      errors.New("unexpected status code: 503"),
+			expected: "Unable to Connect to Fakecloud",
+		},
+		"unreachable": {
+			err:      errors.New("dial tcp 192.0.2.1:443: connect: connection refused"),
+			expected: "Unable to Connect to Fakecloud",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+
+			client := stubCallerIdentityClient{identity: &fakecloud.CallerIdentity{}, err: tc.err}
+			checkCredentials(context.Background(), client, &diags)
+
+			actual := ""
+			if errs := diags.Errors(); len(errs) > 0 {
+				actual = errs[0].Summary()
+			}
+
+			if len(diags) > 1 || actual != tc.expected {
+				t.Errorf("expected error %q, got %v", tc.expected, diags)
+			}
+		})
+	}
+}
+
+// testResourceState returns a state of resource r with the given attributes
+// set and the others null, a null state when attrs is empty.
+func testResourceState(t *testing.T, r resource.Resource, attrs map[string]attr.Value) tfsdk.State {
+	t.Helper()
+
+	ctx := context.Background()
+
+	var resp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected schema error: %v", resp.Diagnostics)
+	}
+
+	state := tfsdk.State{
+		Schema: resp.Schema,
+		Raw:    tftypes.NewValue(resp.Schema.Type().TerraformType(ctx), nil),
+	}
+
+	for name, value := range attrs {
+		if diags := state.SetAttribute(ctx, path.Root(name), value); diags.HasError() {
+			t.Fatalf("unexpected error setting %s: %v", name, diags)
+		}
+	}
+
+	return state
+}
+
+// testRegionalImportState checks the import of regional resource r by
+// <id>, <region>/<id> and <project>/<region>/<id>.
+func testRegionalImportState(t *testing.T, r resource.ResourceWithImportState) {
+	t.Helper()
+
+	cases := map[string]struct {
+		importID string
+		id       int64
+		region   types.String
+		project  types.String
+		err      bool
+	}{
+		"provider region": {
+			importID: "42",
+			id:       42,
+			region:   types.StringNull(),
+			project:  types.StringNull(),
+		},
+		"region": {
+			importID: "eu-central/42",
+			id:       42,
+			region:   types.StringValue("eu-central"),
+			project:  types.StringNull(),
+		},
+		"project and region": {
+			importID: "acme/eu-central/42",
+			id:       42,
+			region:   types.StringValue("eu-central"),
+			project:  types.StringValue("acme"),
+		},
+		"not a number": {
+			importID: "eu-central/web",
+			err:      true,
+		},
+		"too many parts": {
+			importID: "acme/eu-central/7/42",
+			err:      true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			resp := resource.ImportStateResponse{State: testResourceState(t, r, nil)}
+			r.ImportState(ctx, resource.ImportStateRequest{ID: tc.importID}, &resp)
+
+			if tc.err {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			var id types.Int64
+			var region, project types.String
+
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &id)...)
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("region"), &region)...)
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("project"), &project)...)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			if id.ValueInt64() != tc.id || !region.Equal(tc.region) || !project.Equal(tc.project) {
+				t.Errorf("expected id %d, region %s and project %s, got %s, %s and %s", tc.id, tc.region, tc.project, id, region, project)
+			}
+		})
+	}
+}
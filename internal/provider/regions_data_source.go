@@ -0,0 +1,175 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &regionsDataSource{}
+	_ datasource.DataSourceWithConfigure = &regionsDataSource{}
+)
+
+func NewRegionsDataSource() datasource.DataSource {
+	return &regionsDataSource{}
+}
+
+type regionsDataSource struct {
+	providerData *providerData
+}
+
+// regionsDataSourceModel maps the data source schema data.
+type regionsDataSourceModel struct {
+	State   types.String   `tfsdk:"state"`
+	Names   []types.String `tfsdk:"names"`
+	Regions []regionModel  `tfsdk:"regions"`
+}
+
+// regionModel maps region schema data.
+type regionModel struct {
+	Name          types.String   `tfsdk:"name"`
+	Status        types.String   `tfsdk:"status"`
+	InstanceTypes []types.String `tfsdk:"instance_types"`
+}
+
+func (d *regionsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_regions"
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *regionsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.providerData = providerData
+}
+
+// Schema defines the schema for the data source.
+func (d *regionsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the Fakecloud regions.",
+
+		Attributes: map[string]schema.Attribute{
+			"state": schema.StringAttribute{
+				MarkdownDescription: "Only list regions with this status, e.g. `available`.",
+				Optional:            true,
+			},
+			"names": schema.ListAttribute{
+				MarkdownDescription: "Names of the listed regions, sorted.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"regions": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"status": schema.StringAttribute{
+							Computed: true,
+						},
+						"instance_types": schema.ListAttribute{
+							MarkdownDescription: "Instance types offered in the region.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *regionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state regionsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.providerData.client("", "")
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Create Fakecloud API Client", err.Error())
+		return
+	}
+
+	regions, err := client.GetRegions(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Fakecloud Regions",
+			err.Error(),
+		)
+		return
+	}
+
+	state.Names = []types.String{}
+	state.Regions = []regionModel{}
+
+	// Map response body to model
+	for _, region := range filterRegions(regions, state.State.ValueString()) {
+		state.Names = append(state.Names, types.StringValue(region.Name))
+		state.Regions = append(state.Regions, regionModel{
+			Name:          types.StringValue(region.Name),
+			Status:        types.StringValue(region.Status),
+			InstanceTypes: stringValues(region.InstanceTypes),
+		})
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// filterRegions returns the regions with the given status, or all of them
+// when status is empty, sorted by name.
+func filterRegions(regions []fakecloud.Region, status string) []fakecloud.Region {
+	result := make([]fakecloud.Region, 0, len(regions))
+
+	for _, region := range regions {
+		if status != "" && region.Status != status {
+			continue
+		}
+
+		result = append(result, region)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+
+	return result
+}
+
+// stringValues converts a list of strings returned by the API into its
+// Terraform values.
+func stringValues(values []string) []types.String {
+	result := make([]types.String, 0, len(values))
+
+	for _, value := range values {
+		result = append(result, types.StringValue(value))
+	}
+
+	return result
+}
@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+func TestFilterRegions(t *testing.T) {
+	regions := []fakecloud.Region{
+		{Name: "us-west", Status: "available"},
+		{Name: "eu-central", Status: "available"},
+		{Name: "ap-south", Status: "maintenance"},
+	}
+
+	cases := map[string]struct {
+		status   string
+		expected []string
+	}{
+		"all sorted": {
+			expected: []string{"ap-south", "eu-central", "us-west"},
+		},
+		"available": {
+			status:   "available",
+			expected: []string{"eu-central", "us-west"},
+		},
+		"maintenance": {
+			status:   "maintenance",
+			expected: []string{"ap-south"},
+		},
+		"no match": {
+			status:   "retired",
+			expected: []string{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			names := []string{}
+			for _, region := range filterRegions(regions, tc.status) {
+				names = append(names, region.Name)
+			}
+
+			if diff := cmp.Diff(tc.expected, names); diff != "" {
+				t.Errorf("unexpected regions (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// saveCreated stores the identifier, project and region of a resource as soon
+// as the API accepted its creation, before waiting for it to become ready: a
+// failed wait then leaves the resource tainted rather than orphaned.
+func saveCreated(ctx context.Context, resp *resource.CreateResponse, client *apiClient, id types.Int64) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("region"), regionValue(client.region))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project"), projectValue(client.project))...)
+}
+
+// updateReplaceOnly is the Update of resources whose every attribute forces a
+// replacement. Terraform only calls it for changes the API is not involved
+// in, such as the timeouts, so the plan is stored as is.
+func updateReplaceOnly(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.State.Raw = req.Plan.Raw
+}
@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &secretVersionDataSource{}
+	_ datasource.DataSourceWithConfigure = &secretVersionDataSource{}
+)
+
+func NewSecretVersionDataSource() datasource.DataSource {
+	return &secretVersionDataSource{}
+}
+
+type secretVersionDataSource struct {
+	providerData *providerData
+}
+
+// secretVersionDataSourceModel maps the data source schema data.
+type secretVersionDataSourceModel struct {
+	ID        types.Int64  `tfsdk:"id"`
+	SecretID  types.Int64  `tfsdk:"secret_id"`
+	Version   types.Int64  `tfsdk:"version"`
+	Value     types.String `tfsdk:"value"`
+	CreatedAt types.String `tfsdk:"created_at"`
+	Region    types.String `tfsdk:"region"`
+	Project   types.String `tfsdk:"project"`
+}
+
+func (d *secretVersionDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_secret_version"
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *secretVersionDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.providerData = providerData
+}
+
+// Schema defines the schema for the data source.
+func (d *secretVersionDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads the value of a version of a `fakecloud_secret`, the latest one unless `version` is set, " +
+			"e.g. to pass it to a VM through its write-only `user_data`. Unlike with `fakecloud_secret_version`, the value is stored " +
+			"in the state of the data source.",
+
+		Attributes: map[string]schema.Attribute{
+			"secret_id": schema.Int64Attribute{
+				MarkdownDescription: "Identifier of the secret.",
+				Required:            true,
+			},
+			"version": schema.Int64Attribute{
+				MarkdownDescription: "Number of the version to read. Defaults to the latest version.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"id": schema.Int64Attribute{
+				MarkdownDescription: "Secret version identifier.",
+				Computed:            true,
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "Value of the version.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "Creation time of the version, in RFC 3339 format.",
+				Computed:            true,
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Region to look the secret up in. Defaults to the provider region.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project to look the secret up in. Defaults to the provider project.",
+				Optional:            true,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *secretVersionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state secretVersionDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.providerData.client(state.Project.ValueString(), state.Region.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("region"), "Unable to Create Fakecloud API Client", err.Error())
+		return
+	}
+
+	// A null version is read as 0, the latest version.
+	version, err := client.AccessSecretVersion(ctx, int(state.SecretID.ValueInt64()), int(state.Version.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Fakecloud Secret Version",
+			err.Error(),
+		)
+		return
+	}
+
+	// Map response body to model
+	state.ID = types.Int64Value(int64(version.ID))
+	state.Version = types.Int64Value(int64(version.Version))
+	state.Value = types.StringValue(version.Value)
+	state.CreatedAt = types.StringValue(version.CreatedAt.Format(time.RFC3339))
+	state.Region = regionValue(client.region)
+	state.Project = projectValue(client.project)
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
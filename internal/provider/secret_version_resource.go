@@ -0,0 +1,287 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SecretVersionResource{}
+var _ resource.ResourceWithImportState = &SecretVersionResource{}
+var _ resource.ResourceWithModifyPlan = &SecretVersionResource{}
+
+// secretValueMaxSize is the largest secret value, in bytes, the Fakecloud API
+// accepts.
+const secretValueMaxSize = 64 * 1024
+
+func NewSecretVersionResource() resource.Resource {
+	return &SecretVersionResource{}
+}
+
+// SecretVersionResource defines the resource implementation.
+type SecretVersionResource struct {
+	providerData *providerData
+}
+
+// SecretVersionResourceModel describes the resource data model.
+type SecretVersionResourceModel struct {
+	ID        types.Int64  `tfsdk:"id"`
+	SecretID  types.Int64  `tfsdk:"secret_id"`
+	Value     types.String `tfsdk:"value"`
+	ValueHash types.String `tfsdk:"value_hash"`
+	Version   types.Int64  `tfsdk:"version"`
+	CreatedAt types.String `tfsdk:"created_at"`
+	Region    types.String `tfsdk:"region"`
+	Project   types.String `tfsdk:"project"`
+}
+
+func (r *SecretVersionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_secret_version"
+}
+
+func (r *SecretVersionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Version of a `fakecloud_secret`. Versions are immutable: changing the value adds a new version and destroys this one. " +
+			"The value is write-only, only its hash is stored in state. " +
+			"Import with `<secret_id>/<id>`, prefixed with `<region>/` outside the provider region, or `<project>/<region>/` outside the provider project.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Secret version identifier",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"secret_id": schema.Int64Attribute{
+				MarkdownDescription: "Identifier of the secret. Changing it forces a new version to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Value of the version, at most %d bytes. Write-only, only its hash is stored in state. "+
+					"Changing it forces a new version to be created.", secretValueMaxSize),
+				Required:  true,
+				WriteOnly: true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, secretValueMaxSize),
+				},
+			},
+			"value_hash": schema.StringAttribute{
+				MarkdownDescription: "SHA-256 hash of the value, stored in state to detect changes.",
+				Computed:            true,
+			},
+			"version": schema.Int64Attribute{
+				MarkdownDescription: "Number of the version within the secret, starting at 1.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "Creation time of the version, in RFC 3339 format.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Region of the secret. Defaults to the provider region. Changing it forces a new version to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project of the secret. Defaults to the provider project. Changing it forces a new version to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SecretVersionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *SecretVersionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.planScope(ctx, req.Config, req.State, req.Plan, resp)
+
+	// Nothing more to plan when the version is destroyed or the provider is
+	// not configured yet.
+	if resp.Diagnostics.HasError() || req.Plan.Raw.IsNull() || r.providerData == nil {
+		return
+	}
+
+	var config, plan, state SecretVersionResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only a hash of the write-only value is planned and kept in state.
+	plan.ValueHash = writeOnlyHash(config.Value)
+
+	if !req.State.Raw.IsNull() && !plan.ValueHash.Equal(state.ValueHash) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("value_hash"))
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *SecretVersionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var config, data SecretVersionResourceModel
+
+	// The write-only value is only available in the configuration.
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	version, err := client.AddSecretVersion(ctx, int(data.SecretID.ValueInt64()), config.Value.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to add secret version", err.Error())
+		return
+	}
+
+	setSecretVersion(&data, client, version)
+
+	tflog.Trace(ctx, "added a secret version")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SecretVersionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SecretVersionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	secretID := int(data.SecretID.ValueInt64())
+
+	version, err := client.GetSecretVersion(ctx, secretID, int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read secret version, got error: %s", err), err.Error())
+		return
+	}
+
+	setSecretVersion(&data, client, version)
+
+	// Versions are immutable, the value only needs to be read to hash it
+	// after an import.
+	if data.ValueHash.IsNull() {
+		accessed, err := client.AccessSecretVersion(ctx, secretID, version.Version)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Unable to read secret version value, got error: %s", err), err.Error())
+			return
+		}
+
+		data.ValueHash = writeOnlyHash(types.StringValue(accessed.Value))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SecretVersionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	updateReplaceOnly(ctx, req, resp)
+}
+
+func (r *SecretVersionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SecretVersionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	err := client.DestroySecretVersion(ctx, int(data.SecretID.ValueInt64()), int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to destroy secret version, got error: %s", err), err.Error())
+		return
+	}
+}
+
+func (r *SecretVersionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importRegionalChild(ctx, req, resp, "secret_id", "id")
+}
+
+// setSecretVersion copies the version returned by the API into the model. The
+// API does not return the value, its hash is left as planned.
+func setSecretVersion(data *SecretVersionResourceModel, client *apiClient, version *fakecloud.SecretVersion) {
+	data.ID = types.Int64Value(int64(version.ID))
+	data.SecretID = types.Int64Value(int64(version.SecretID))
+	data.Version = types.Int64Value(int64(version.Version))
+	data.CreatedAt = types.StringValue(version.CreatedAt.Format(time.RFC3339))
+	data.Region = regionValue(client.region)
+	data.Project = projectValue(client.project)
+}
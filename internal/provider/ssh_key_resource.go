@@ -0,0 +1,315 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+	"golang.org/x/crypto/ssh"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SSHKeyResource{}
+var _ resource.ResourceWithImportState = &SSHKeyResource{}
+var _ resource.ResourceWithModifyPlan = &SSHKeyResource{}
+
+func NewSSHKeyResource() resource.Resource {
+	return &SSHKeyResource{}
+}
+
+// SSHKeyResource defines the resource implementation.
+type SSHKeyResource struct {
+	providerData *providerData
+}
+
+// SSHKeyResourceModel describes the resource data model.
+type SSHKeyResourceModel struct {
+	ID          types.Int64  `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	PublicKey   types.String `tfsdk:"public_key"`
+	Fingerprint types.String `tfsdk:"fingerprint"`
+	Region      types.String `tfsdk:"region"`
+	Project     types.String `tfsdk:"project"`
+}
+
+func (r *SSHKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ssh_key"
+}
+
+func (r *SSHKeyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "SSH public key that can be injected into virtual machines through their `ssh_key_ids`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "SSH key identifier",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the SSH key",
+				Required:            true,
+			},
+			"public_key": schema.StringAttribute{
+				MarkdownDescription: "Public key in OpenSSH authorized_keys format, e.g. `ssh-ed25519 AAAA... user@host`. " +
+					"Changing it forces a new SSH key to be created.",
+				Required: true,
+				Validators: []validator.String{
+					sshPublicKeyValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"fingerprint": schema.StringAttribute{
+				MarkdownDescription: "SHA256 fingerprint of the public key, as printed by `ssh-keygen -l`.",
+				Computed:            true,
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Region the SSH key is registered in. Defaults to the provider region. Changing it forces a new SSH key to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project the SSH key belongs to. Defaults to the provider project. Changing it forces a new SSH key to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SSHKeyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *SSHKeyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.planScope(ctx, req.Config, req.State, req.Plan, resp)
+
+	// Nothing more to plan when the SSH key is destroyed or the provider is
+	// not configured yet.
+	if resp.Diagnostics.HasError() || req.Plan.Raw.IsNull() || r.providerData == nil {
+		return
+	}
+
+	var plan, state SSHKeyResourceModel
+
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The fingerprint is computed locally so it is known at plan time.
+	if !plan.PublicKey.IsUnknown() {
+		fingerprint, err := sshKeyFingerprint(plan.PublicKey.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("public_key"), "Invalid SSH Public Key", err.Error())
+			return
+		}
+
+		plan.Fingerprint = types.StringValue(fingerprint)
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *SSHKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SSHKeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	key, err := client.CreateSSHKey(ctx, &fakecloud.SSHKey{
+		Name:      data.Name.ValueString(),
+		PublicKey: data.PublicKey.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to create SSH key", err.Error())
+		return
+	}
+
+	data.ID = types.Int64Value(int64(key.ID))
+	data.Region = regionValue(client.region)
+	data.Project = projectValue(client.project)
+
+	tflog.Trace(ctx, "created an SSH key")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SSHKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SSHKeyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	key, err := client.GetSSHKey(ctx, int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read SSH key, got error: %s", err), err.Error())
+		return
+	}
+
+	data.Name = types.StringValue(key.Name)
+	data.Region = regionValue(client.region)
+	data.Project = projectValue(client.project)
+
+	// The API may normalise the key, e.g. drop its comment. Keep the
+	// configured form unless it is a different key altogether.
+	fingerprint, err := sshKeyFingerprint(key.PublicKey)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to parse SSH key returned by the API", err.Error())
+		return
+	}
+
+	if !data.Fingerprint.Equal(types.StringValue(fingerprint)) {
+		data.PublicKey = types.StringValue(key.PublicKey)
+		data.Fingerprint = types.StringValue(fingerprint)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SSHKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SSHKeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	err := client.UpdateSSHKey(ctx, int(data.ID.ValueInt64()), data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to update SSH key, got error: %s", err), err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SSHKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SSHKeyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	err := client.DeleteSSHKey(ctx, int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to delete SSH key, got error: %s", err), err.Error())
+		return
+	}
+}
+
+func (r *SSHKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importRegional(ctx, req, resp)
+}
+
+// sshKeyFingerprint parses a public key in authorized_keys format and returns
+// its SHA256 fingerprint.
+func sshKeyFingerprint(publicKey string) (string, error) {
+	key, _, _, rest, err := ssh.ParseAuthorizedKey([]byte(publicKey))
+	if err != nil {
+		return "", fmt.Errorf("unable to parse public key: %w", err)
+	}
+
+	if len(rest) > 0 {
+		return "", fmt.Errorf("expected a single public key, got more than one")
+	}
+
+	return ssh.FingerprintSHA256(key), nil
+}
+
+var _ validator.String = sshPublicKeyValidator{}
+
+// sshPublicKeyValidator validates that a string is a single public key in
+// authorized_keys format.
+type sshPublicKeyValidator struct{}
+
+func (v sshPublicKeyValidator) Description(ctx context.Context) string {
+	return "value must be a single public key in OpenSSH authorized_keys format"
+}
+
+func (v sshPublicKeyValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v sshPublicKeyValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := sshKeyFingerprint(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid SSH Public Key", err.Error())
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation scope of every span emitted by the provider.
+const tracerName = "terraform-provider-fakecloud"
+
+// Span attribute keys shared by the RPC and API call spans.
+const (
+	attrResourceType         = attribute.Key("fakecloud.resource_type")
+	attrVMID                 = attribute.Key("fakecloud.vm.id")
+	attrSSHKeyID             = attribute.Key("fakecloud.ssh_key.id")
+	attrSnapshotID           = attribute.Key("fakecloud.snapshot.id")
+	attrImageID              = attribute.Key("fakecloud.image.id")
+	attrFloatingIPID         = attribute.Key("fakecloud.floating_ip.id")
+	attrLoadBalancerID       = attribute.Key("fakecloud.load_balancer.id")
+	attrDNSZoneID            = attribute.Key("fakecloud.dns_zone.id")
+	attrDNSRecordID          = attribute.Key("fakecloud.dns_record.id")
+	attrBucketName           = attribute.Key("fakecloud.bucket.name")
+	attrBucketObjectKey      = attribute.Key("fakecloud.bucket_object.key")
+	attrIAMUserID            = attribute.Key("fakecloud.iam_user.id")
+	attrIAMRoleID            = attribute.Key("fakecloud.iam_role.id")
+	attrIAMPolicyID          = attribute.Key("fakecloud.iam_policy.id")
+	attrInstanceGroupID      = attribute.Key("fakecloud.instance_group.id")
+	attrAutoscalingPolicyID  = attribute.Key("fakecloud.autoscaling_policy.id")
+	attrProjectName          = attribute.Key("fakecloud.project.name")
+	attrKubernetesClusterID  = attribute.Key("fakecloud.kubernetes_cluster.id")
+	attrKubernetesNodePoolID = attribute.Key("fakecloud.kubernetes_node_pool.id")
+	attrDatabaseInstanceID   = attribute.Key("fakecloud.database_instance.id")
+	attrDatabaseUserID       = attribute.Key("fakecloud.database_user.id")
+	attrSecretID             = attribute.Key("fakecloud.secret.id")
+	attrSecretVersionID      = attribute.Key("fakecloud.secret_version.id")
+	attrFirewallPolicyID     = attribute.Key("fakecloud.firewall_policy.id")
+	attrNetworkID            = attribute.Key("fakecloud.network.id")
+	attrBackupPolicyID       = attribute.Key("fakecloud.backup_policy.id")
+	attrRegion               = attribute.Key("fakecloud.region")
+	attrProject              = attribute.Key("fakecloud.project")
+	attrHTTPStatusCode       = attribute.Key("http.response.status_code")
+	attrThrottleWait         = attribute.Key("fakecloud.throttle_wait_ms")
+)
+
+// ConfigureTracing installs a global OpenTelemetry tracer provider exporting
+// spans over OTLP. Tracing is opt-in: unless one of the standard
+// OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT
+// environment variables is set, nothing is installed and spans are dropped.
+//
+// The returned function flushes and stops the exporter and must be called
+// before the provider process exits.
+func ConfigureTracing(ctx context.Context, version string) (func(context.Context) error, error) {
+	noop := func(context.Context) error { return nil }
+
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" && os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") == "" {
+		return noop, nil
+	}
+
+	// Both clients read the remaining OTEL_EXPORTER_OTLP_* variables
+	// (headers, TLS, timeouts) on their own.
+	var client otlptrace.Client
+
+	switch otlpProtocol() {
+	case "grpc":
+		client = otlptracegrpc.NewClient()
+	default:
+		client = otlptracehttp.NewClient()
+	}
+
+	exporter, err := otlptrace.New(ctx, client)
+	if err != nil {
+		return noop, err
+	}
+
+	res, err := sdkresource.Merge(
+		sdkresource.Default(),
+		sdkresource.NewSchemaless(
+			attribute.String("service.name", tracerName),
+			attribute.String("service.version", version),
+		),
+	)
+	if err != nil {
+		return noop, err
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return tp.Shutdown, nil
+}
+
+// otlpProtocol returns the OTLP transport requested through the environment,
+// preferring the traces specific variable as the OpenTelemetry spec requires.
+func otlpProtocol() string {
+	if protocol := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_PROTOCOL"); protocol != "" {
+		return protocol
+	}
+
+	return os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL")
+}
+
+// NewTracedProtocol6 behaves like providerserver.NewProtocol6 but records a
+// span for every plan, apply and read RPC served for p.
+func NewTracedProtocol6(p provider.Provider) func() tfprotov6.ProviderServer {
+	server := providerserver.NewProtocol6(p)
+
+	return func() tfprotov6.ProviderServer {
+		return newTracingProviderServer(server(), otel.Tracer(tracerName), environmentParent())
+	}
+}
+
+// environmentParent returns the span context propagated to the provider by
+// the process running Terraform through the TRACEPARENT and TRACESTATE
+// environment variables, or an invalid span context if there is none.
+func environmentParent() trace.SpanContext {
+	carrier := propagation.MapCarrier{
+		"traceparent": os.Getenv("TRACEPARENT"),
+		"tracestate":  os.Getenv("TRACESTATE"),
+	}
+
+	ctx := propagation.TraceContext{}.Extract(context.Background(), carrier)
+
+	return trace.SpanContextFromContext(ctx)
+}
+
+// Ensure the implementation satisfies the protocol interface.
+var _ tfprotov6.ProviderServer = &tracingProviderServer{}
+
+// tracingProviderServer wraps a protocol server and records a span for every
+// RPC that talks to the Fakecloud API. All other RPCs are passed through
+// untouched.
+type tracingProviderServer struct {
+	tfprotov6.ProviderServer
+
+	tracer trace.Tracer
+	parent trace.SpanContext
+}
+
+func newTracingProviderServer(server tfprotov6.ProviderServer, tracer trace.Tracer, parent trace.SpanContext) *tracingProviderServer {
+	return &tracingProviderServer{
+		ProviderServer: server,
+		tracer:         tracer,
+		parent:         parent,
+	}
+}
+
+func (s *tracingProviderServer) start(ctx context.Context, rpc string, typeName string) (context.Context, trace.Span) {
+	if s.parent.IsValid() && !trace.SpanContextFromContext(ctx).IsValid() {
+		ctx = trace.ContextWithRemoteSpanContext(ctx, s.parent)
+	}
+
+	return s.tracer.Start(ctx, rpc,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attrResourceType.String(typeName)),
+	)
+}
+
+// endSpan records the diagnostics returned by an RPC on its span and ends it.
+func endSpan(span trace.Span, err error, diags []*tfprotov6.Diagnostic) {
+	defer span.End()
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	for _, diag := range diags {
+		if diag != nil && diag.Severity == tfprotov6.DiagnosticSeverityError {
+			span.SetStatus(codes.Error, diag.Summary)
+			return
+		}
+	}
+}
+
+func (s *tracingProviderServer) ReadResource(ctx context.Context, req *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error) {
+	ctx, span := s.start(ctx, "ReadResource", req.TypeName)
+
+	resp, err := s.ProviderServer.ReadResource(ctx, req)
+	if resp != nil {
+		endSpan(span, err, resp.Diagnostics)
+	} else {
+		endSpan(span, err, nil)
+	}
+
+	return resp, err
+}
+
+func (s *tracingProviderServer) PlanResourceChange(ctx context.Context, req *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error) {
+	ctx, span := s.start(ctx, "PlanResourceChange", req.TypeName)
+
+	resp, err := s.ProviderServer.PlanResourceChange(ctx, req)
+	if resp != nil {
+		endSpan(span, err, resp.Diagnostics)
+	} else {
+		endSpan(span, err, nil)
+	}
+
+	return resp, err
+}
+
+func (s *tracingProviderServer) ApplyResourceChange(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+	ctx, span := s.start(ctx, "ApplyResourceChange", req.TypeName)
+
+	resp, err := s.ProviderServer.ApplyResourceChange(ctx, req)
+	if resp != nil {
+		endSpan(span, err, resp.Diagnostics)
+	} else {
+		endSpan(span, err, nil)
+	}
+
+	return resp, err
+}
+
+func (s *tracingProviderServer) ImportResourceState(ctx context.Context, req *tfprotov6.ImportResourceStateRequest) (*tfprotov6.ImportResourceStateResponse, error) {
+	ctx, span := s.start(ctx, "ImportResourceState", req.TypeName)
+
+	resp, err := s.ProviderServer.ImportResourceState(ctx, req)
+	if resp != nil {
+		endSpan(span, err, resp.Diagnostics)
+	} else {
+		endSpan(span, err, nil)
+	}
+
+	return resp, err
+}
+
+func (s *tracingProviderServer) ReadDataSource(ctx context.Context, req *tfprotov6.ReadDataSourceRequest) (*tfprotov6.ReadDataSourceResponse, error) {
+	ctx, span := s.start(ctx, "ReadDataSource", req.TypeName)
+
+	resp, err := s.ProviderServer.ReadDataSource(ctx, req)
+	if resp != nil {
+		endSpan(span, err, resp.Diagnostics)
+	} else {
+		endSpan(span, err, nil)
+	}
+
+	return resp, err
+}
@@ -0,0 +1,159 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newTestTracer(t *testing.T) (trace.Tracer, *tracetest.InMemoryExporter) {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	return tp.Tracer(tracerName), exporter
+}
+
+func spanAttribute(span tracetest.SpanStub, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range span.Attributes {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+
+	return attribute.Value{}, false
+}
+
+// stubProviderServer answers PlanResourceChange and panics on any other RPC.
+type stubProviderServer struct {
+	tfprotov6.ProviderServer
+
+	diagnostics []*tfprotov6.Diagnostic
+}
+
+func (s stubProviderServer) PlanResourceChange(ctx context.Context, req *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error) {
+	return &tfprotov6.PlanResourceChangeResponse{Diagnostics: s.diagnostics}, nil
+}
+
+func TestInvokeRecordsSpan(t *testing.T) {
+	tracer, exporter := newTestTracer(t)
+	client := newAPIClient(nil, tracer, apiLimits{})
+
+	err := invokeNoResult(context.Background(), client, "GetVM", func() error {
+		return fmt.Errorf("unexpected status code: %d", 404)
+	}, attrVMID.Int(42))
+	if err == nil {
+		t.Fatal("expected the call error to be returned")
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+
+	span := spans[0]
+	if span.Name != "fakecloud.GetVM" {
+		t.Errorf("unexpected span name %q", span.Name)
+	}
+	if span.SpanKind != trace.SpanKindClient {
+		t.Errorf("unexpected span kind %s", span.SpanKind)
+	}
+	if span.Status.Code != codes.Error {
+		t.Errorf("expected error status, got %s", span.Status.Code)
+	}
+	if v, ok := spanAttribute(span, attrVMID); !ok || v.AsInt64() != 42 {
+		t.Errorf("expected %s=42, got %v", attrVMID, v.Emit())
+	}
+	if v, ok := spanAttribute(span, attrHTTPStatusCode); !ok || v.AsInt64() != 404 {
+		t.Errorf("expected %s=404, got %v", attrHTTPStatusCode, v.Emit())
+	}
+}
+
+func TestInvokeNestsUnderContextSpan(t *testing.T) {
+	tracer, exporter := newTestTracer(t)
+	client := newAPIClient(nil, tracer, apiLimits{})
+
+	ctx, parent := tracer.Start(context.Background(), "parent")
+	_, err := invoke(ctx, client, "GetVMs", func() (int, error) { return 0, nil })
+	parent.End()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+
+	child, root := spans[0], spans[1]
+	if child.Parent.SpanID() != root.SpanContext.SpanID() {
+		t.Errorf("expected %q to be a child of %q", child.Name, root.Name)
+	}
+	if child.Status.Code != codes.Unset {
+		t.Errorf("expected unset status, got %s", child.Status.Code)
+	}
+	if v, ok := spanAttribute(child, attrHTTPStatusCode); !ok || v.AsInt64() != 200 {
+		t.Errorf("expected %s=200 on a successful call, got %v", attrHTTPStatusCode, v.Emit())
+	}
+}
+
+func TestTracingProviderServer(t *testing.T) {
+	t.Setenv("TRACEPARENT", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	tracer, exporter := newTestTracer(t)
+	server := newTracingProviderServer(stubProviderServer{
+		diagnostics: []*tfprotov6.Diagnostic{
+			{Severity: tfprotov6.DiagnosticSeverityError, Summary: "boom"},
+		},
+	}, tracer, environmentParent())
+
+	_, err := server.PlanResourceChange(context.Background(), &tfprotov6.PlanResourceChangeRequest{
+		TypeName: "fakecloud_virtual_machine",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+
+	span := spans[0]
+	if span.Name != "PlanResourceChange" {
+		t.Errorf("unexpected span name %q", span.Name)
+	}
+	if got := span.SpanContext.TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("expected span to join the TRACEPARENT trace, got trace %s", got)
+	}
+	if got := span.Parent.SpanID().String(); got != "00f067aa0ba902b7" {
+		t.Errorf("expected TRACEPARENT span as parent, got %s", got)
+	}
+	if v, ok := spanAttribute(span, attrResourceType); !ok || v.AsString() != "fakecloud_virtual_machine" {
+		t.Errorf("expected %s=fakecloud_virtual_machine, got %v", attrResourceType, v.Emit())
+	}
+	if span.Status.Code != codes.Error || span.Status.Description != "boom" {
+		t.Errorf("expected error status from diagnostics, got %s %q", span.Status.Code, span.Status.Description)
+	}
+}
+
+func TestEnvironmentParentUnset(t *testing.T) {
+	t.Setenv("TRACEPARENT", "")
+
+	if environmentParent().IsValid() {
+		t.Error("expected no parent without TRACEPARENT")
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// userDataMaxSize is the largest user data, in bytes before any base64
+// encoding, the Fakecloud API accepts.
+const userDataMaxSize = 16 * 1024
+
+// userDataContent returns the user data configured either as plain text or
+// base64 encoded, null when neither is set and unknown while either is
+// unknown.
+func userDataContent(userData types.String, userDataBase64 types.String) (types.String, error) {
+	if userData.IsUnknown() || userDataBase64.IsUnknown() {
+		return types.StringUnknown(), nil
+	}
+
+	if !userData.IsNull() {
+		return userData, nil
+	}
+
+	if userDataBase64.IsNull() {
+		return types.StringNull(), nil
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(userDataBase64.ValueString())
+	if err != nil {
+		return types.StringNull(), fmt.Errorf("user_data_base64 is not valid base64: %w", err)
+	}
+
+	return types.StringValue(string(decoded)), nil
+}
+
+var _ validator.String = base64UserDataValidator{}
+
+// base64UserDataValidator validates that a string is base64 encoded and does
+// not exceed userDataMaxSize once decoded.
+type base64UserDataValidator struct{}
+
+func (v base64UserDataValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be base64 encoded and at most %d bytes once decoded", userDataMaxSize)
+}
+
+func (v base64UserDataValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v base64UserDataValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Base64 User Data", fmt.Sprintf("The value is not valid base64: %s", err))
+		return
+	}
+
+	if len(decoded) > userDataMaxSize {
+		resp.Diagnostics.AddAttributeError(req.Path, "User Data Too Large",
+			fmt.Sprintf("The decoded user data is %d bytes, the maximum is %d bytes.", len(decoded), userDataMaxSize))
+	}
+}
+
+// refreshedUserDataHash returns the user data hash to store in state for the
+// user data read from the API. The API omits user_data from some responses,
+// the prior hash is kept when it returns none: dropping it would replace the
+// VM under the default user_data_replace_on_change.
+func refreshedUserDataHash(prior types.String, userData string) types.String {
+	if userData == "" {
+		return prior
+	}
+
+	return writeOnlyHash(types.StringValue(userData))
+}
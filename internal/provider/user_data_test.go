@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestUserDataContentHash(t *testing.T) {
+	const cloudConfig = "#cloud-config\npackages:\n  - nginx\n"
+
+	cases := map[string]struct {
+		userData       types.String
+		userDataBase64 types.String
+		expected       types.String
+		err            bool
+	}{
+		"none": {
+			userData:       types.StringNull(),
+			userDataBase64: types.StringNull(),
+			expected:       types.StringNull(),
+		},
+		"plain": {
+			userData:       types.StringValue(cloudConfig),
+			userDataBase64: types.StringNull(),
+			expected:       types.StringValue("dc2c5f17fe06c37fedf411dfd755406336c85f3b4a0206bdd7f2d95b7a78d005"),
+		},
+		"base64 hashes the decoded content": {
+			userData:       types.StringNull(),
+			userDataBase64: types.StringValue("I2Nsb3VkLWNvbmZpZwpwYWNrYWdlczoKICAtIG5naW54Cg=="),
+			expected:       types.StringValue("dc2c5f17fe06c37fedf411dfd755406336c85f3b4a0206bdd7f2d95b7a78d005"),
+		},
+		"unknown": {
+			userData:       types.StringUnknown(),
+			userDataBase64: types.StringNull(),
+			expected:       types.StringUnknown(),
+		},
+		"invalid base64": {
+			userData:       types.StringNull(),
+			userDataBase64: types.StringValue("not base64!"),
+			err:            true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			content, err := userDataContent(tc.userData, tc.userDataBase64)
+			if tc.err {
+				if err == nil {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got := writeOnlyHash(content); !got.Equal(tc.expected) {
+				t.Errorf("expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestRefreshedUserDataHash(t *testing.T) {
+	const cloudConfig = "#cloud-config\npackages:\n  - nginx\n"
+	const cloudConfigHash = "dc2c5f17fe06c37fedf411dfd755406336c85f3b4a0206bdd7f2d95b7a78d005"
+
+	cases := map[string]struct {
+		prior    types.String
+		userData string
+		expected types.String
+	}{
+		"returned": {
+			prior:    types.StringNull(),
+			userData: cloudConfig,
+			expected: types.StringValue(cloudConfigHash),
+		},
+		"changed outside of Terraform": {
+			prior:    types.StringValue("0000"),
+			userData: cloudConfig,
+			expected: types.StringValue(cloudConfigHash),
+		},
+		"omitted keeps the prior hash": {
+			prior:    types.StringValue(cloudConfigHash),
+			expected: types.StringValue(cloudConfigHash),
+		},
+		"none": {
+			prior:    types.StringNull(),
+			expected: types.StringNull(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := refreshedUserDataHash(tc.prior, tc.userData); !got.Equal(tc.expected) {
+				t.Errorf("expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
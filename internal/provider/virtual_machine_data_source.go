@@ -6,8 +6,8 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	fakecloud "github.com/pokgak/fakecloud/sdk"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -21,7 +21,7 @@ func NewVirtualMachineDataSource() datasource.DataSource {
 }
 
 type virtualMachineDataSource struct {
-	client *fakecloud.Client
+	providerData *providerData
 }
 
 // virtualMachineDataSourceModel maps the data source schema data.
@@ -29,6 +29,8 @@ type virtualMachineDataSourceModel struct {
 	ID           types.Int64  `tfsdk:"id"`
 	Name         types.String `tfsdk:"name"`
 	InstanceType types.String `tfsdk:"instance_type"`
+	Region       types.String `tfsdk:"region"`
+	Project      types.String `tfsdk:"project"`
 }
 
 func (d *virtualMachineDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -41,17 +43,17 @@ func (d *virtualMachineDataSource) Configure(_ context.Context, req datasource.C
 		return
 	}
 
-	client, ok := req.ProviderData.(*fakecloud.Client)
+	providerData, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *fakecloud.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.providerData = providerData
 }
 
 // Schema defines the schema for the data source.
@@ -67,6 +69,16 @@ func (d *virtualMachineDataSource) Schema(_ context.Context, _ datasource.Schema
 			"instance_type": schema.StringAttribute{
 				Computed: true,
 			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Region to look the VM up in. Defaults to the provider region.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project to look the VM up in. Defaults to the provider project.",
+				Optional:            true,
+				Computed:            true,
+			},
 		},
 	}
 }
@@ -77,8 +89,17 @@ func (d *virtualMachineDataSource) Read(ctx context.Context, req datasource.Read
 
 	// Read Terraform configuration data into the model
     resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.providerData.client(state.Project.ValueString(), state.Region.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("region"), "Unable to Create Fakecloud API Client", err.Error())
+		return
+	}
 
-	vm, err := d.client.GetVM(int(state.ID.ValueInt64()))
+	vm, err := client.GetVM(ctx, int(state.ID.ValueInt64()))
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Fakecloud VM",
@@ -89,6 +110,8 @@ func (d *virtualMachineDataSource) Read(ctx context.Context, req datasource.Read
 
 	state.Name = types.StringValue(vm.Name)
 	state.InstanceType = types.StringValue(vm.InstanceType)
+	state.Region = regionValue(client.region)
+	state.Project = projectValue(client.project)
 
 	// Set state
 	diags := resp.State.Set(ctx, &state)
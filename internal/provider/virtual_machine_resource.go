@@ -6,12 +6,19 @@ package provider
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	fakecloud "github.com/pokgak/fakecloud/sdk"
@@ -20,6 +27,21 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &VirtualMachineResource{}
 var _ resource.ResourceWithImportState = &VirtualMachineResource{}
+var _ resource.ResourceWithModifyPlan = &VirtualMachineResource{}
+
+// Statuses reported by the Fakecloud API for a VM.
+const (
+	vmStatusProvisioning = "provisioning"
+	vmStatusRunning      = "running"
+	vmStatusStopping     = "stopping"
+	vmStatusStopped      = "stopped"
+	vmStatusStarting     = "starting"
+	vmStatusResizing     = "resizing"
+)
+
+// defaultVMUpdateTimeout bounds an update, including a resize, when the
+// configuration does not set timeouts.update.
+const defaultVMUpdateTimeout = 20 * time.Minute
 
 func NewVirtualMachineResource() resource.Resource {
 	return &VirtualMachineResource{}
@@ -27,7 +49,7 @@ func NewVirtualMachineResource() resource.Resource {
 
 // VirtualMachineResource defines the resource implementation.
 type VirtualMachineResource struct {
-	client *fakecloud.Client
+	providerData *providerData
 }
 
 // VirtualMachineResourceModel describes the resource data model.
@@ -35,6 +57,23 @@ type VirtualMachineResourceModel struct {
 	ID           types.Int64  `tfsdk:"id"`
 	Name         types.String `tfsdk:"name"`
 	InstanceType types.String `tfsdk:"instance_type"`
+	Region       types.String `tfsdk:"region"`
+	Project      types.String `tfsdk:"project"`
+	ImageID      types.Int64  `tfsdk:"image_id"`
+	PrivateIP    types.String `tfsdk:"private_ip"`
+	PublicIP     types.String `tfsdk:"public_ip"`
+
+	DeletionProtection     types.Bool `tfsdk:"deletion_protection"`
+	AllowStoppingForUpdate types.Bool `tfsdk:"allow_stopping_for_update"`
+
+	SSHKeyIDs types.Set `tfsdk:"ssh_key_ids"`
+
+	UserData                types.String `tfsdk:"user_data"`
+	UserDataBase64          types.String `tfsdk:"user_data_base64"`
+	UserDataHash            types.String `tfsdk:"user_data_hash"`
+	UserDataReplaceOnChange types.Bool   `tfsdk:"user_data_replace_on_change"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *VirtualMachineResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -59,48 +98,330 @@ func (r *VirtualMachineResource) Schema(ctx context.Context, req resource.Schema
 				Required:            true,
 			},
 			"instance_type": schema.StringAttribute{
-				MarkdownDescription: "Instance type for the VM",
-				Required:            true,
+				MarkdownDescription: "Instance type for the VM. Changing it resizes the VM in place, stopping it first when moving to another family " +
+					"(see `allow_stopping_for_update`). Changing the architecture forces a new VM to be created.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						instanceTypeRequiresReplace,
+						"Changing the architecture of the instance type forces a new VM to be created.",
+						"Changing the architecture of the instance type forces a new VM to be created.",
+					),
+				},
+			},
+			"image_id": schema.Int64Attribute{
+				MarkdownDescription: "Identifier of the image the VM boots from, e.g. from the `fakecloud_image` data source. " +
+					"Changing it forces a new VM to be created.",
+				Optional: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"private_ip": schema.StringAttribute{
+				MarkdownDescription: "Private IP address of the VM, kept for its lifetime.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"public_ip": schema.StringAttribute{
+				MarkdownDescription: "Public IP address of the VM, that of its `fakecloud_floating_ip` when one is associated. " +
+					"Null when the VM has no public address.",
+				Computed: true,
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Region the VM is created in. Defaults to the provider region. Changing it forces a new VM to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project the VM belongs to. Defaults to the provider project. Changing it forces a new VM to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: "Whether destroying or replacing the VM fails. Must be set to `false` in a prior apply before the VM can be destroyed. " +
+					"Defaults to the provider `deletion_protection`.",
+				Optional: true,
+				Computed: true,
+			},
+			"allow_stopping_for_update": schema.BoolAttribute{
+				MarkdownDescription: "Whether the VM may be stopped to change its `instance_type` to another family. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"ssh_key_ids": schema.SetAttribute{
+				MarkdownDescription: "Identifiers of `fakecloud_ssh_key`s whose public keys are authorized on the VM. " +
+					"Changing them forces a new VM to be created.",
+				ElementType: types.Int64Type,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"user_data": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("User data, e.g. a cloud-init configuration, passed to the VM. At most %d bytes. "+
+					"Write-only, only its hash is stored in state. Conflicts with `user_data_base64`.", userDataMaxSize),
+				Optional:  true,
+				WriteOnly: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtMost(userDataMaxSize),
+					stringvalidator.ConflictsWith(path.MatchRoot("user_data_base64")),
+				},
+			},
+			"user_data_base64": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Base64 encoded user data, for content that is not valid UTF-8 such as gzipped cloud-init. "+
+					"At most %d bytes once decoded. Write-only, only its hash is stored in state. Conflicts with `user_data`.", userDataMaxSize),
+				Optional:  true,
+				WriteOnly: true,
+				Validators: []validator.String{
+					base64UserDataValidator{},
+					stringvalidator.ConflictsWith(path.MatchRoot("user_data")),
+				},
+			},
+			"user_data_hash": schema.StringAttribute{
+				MarkdownDescription: "SHA-256 hash of the user data, after base64 decoding, stored in state to detect changes.",
+				Computed:            true,
+			},
+			"user_data_replace_on_change": schema.BoolAttribute{
+				MarkdownDescription: "Whether a change of the user data forces a new VM to be created. When `false` the new user data " +
+					"is updated in place and only applies from the next boot of the VM. Defaults to `true`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
 			},
 		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Update: true,
+			}),
+		},
 	}
 }
 
+// instanceTypeRequiresReplace replaces the VM when its instance type cannot
+// be resized into the planned one.
+func instanceTypeRequiresReplace(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	if req.StateValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+
+	resp.RequiresReplace = resizeModeBetween(req.StateValue.ValueString(), req.PlanValue.ValueString()) == resizeUnsupported
+}
+
 func (r *VirtualMachineResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*fakecloud.Client)
+	providerData, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.providerData = providerData
+}
+
+func (r *VirtualMachineResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var state VirtualMachineResourceModel
+
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// The VM is destroyed.
+	if req.Plan.Raw.IsNull() {
+		if state.DeletionProtection.ValueBool() {
+			resp.Diagnostics.AddWarning(
+				"Deletion Protection Enabled",
+				fmt.Sprintf("VM %d has deletion_protection enabled and will fail to be destroyed. "+
+					"Set deletion_protection to false and apply that change first.", state.ID.ValueInt64()),
+			)
+		}
+
+		return
+	}
+
+	r.providerData.planScope(ctx, req.Config, req.State, req.Plan, resp)
+
+	// Nothing more to do until the provider is configured.
+	if resp.Diagnostics.HasError() || r.providerData == nil {
+		return
+	}
+
+	var config, plan VirtualMachineResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.DeletionProtection.IsNull() {
+		plan.DeletionProtection = types.BoolValue(r.providerData.deletionProtection)
+	}
+
+	// Only a hash of the write-only user data is planned and kept in state.
+	userData, err := userDataContent(config.UserData, config.UserDataBase64)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("user_data_base64"), "Invalid User Data", err.Error())
+		return
+	}
+
+	plan.UserDataHash = writeOnlyHash(userData)
+
+	if !req.State.Raw.IsNull() && !plan.UserDataHash.Equal(state.UserDataHash) && plan.UserDataReplaceOnChange.ValueBool() {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("user_data_hash"))
+	}
+
+	// Catch resizes that would need a stop at plan time rather than halfway
+	// through the apply. A replaced VM is created with the new instance type
+	// instead of being resized.
+	if !req.State.Raw.IsNull() && len(resp.RequiresReplace) == 0 && !virtualMachineReplaced(state, plan) &&
+		!plan.AllowStoppingForUpdate.ValueBool() && resizeNeedsStop(state, plan) {
+		client := r.providerData.clientFor(state.Project, state.Region, &resp.Diagnostics)
+		if client == nil {
+			return
+		}
+
+		// A VM already stopped is resized as it is.
+		stopped, err := vmStopped(ctx, client, int(state.ID.ValueInt64()))
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Unable to read VM, got error: %s", err), err.Error())
+			return
+		}
+
+		if !stopped {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("instance_type"),
+				"VM Must Be Stopped To Change Instance Type",
+				fmt.Sprintf("Changing the instance type of VM %d from %s to %s requires stopping it. "+
+					"Set allow_stopping_for_update to true to allow the provider to stop and restart the VM.",
+					state.ID.ValueInt64(), state.InstanceType.ValueString(), plan.InstanceType.ValueString()),
+			)
+		}
+	}
+
+	// Replacing a protected VM destroys it, which Delete refuses to do. Only
+	// the value in state counts, the protection has to be lifted by a prior
+	// apply.
+	if state.DeletionProtection.ValueBool() && (len(resp.RequiresReplace) > 0 || virtualMachineReplaced(state, plan)) {
+		resp.Diagnostics.AddWarning(
+			"Deletion Protection Enabled",
+			fmt.Sprintf("VM %d has deletion_protection enabled but the planned changes replace it, so the apply will fail. "+
+				"Set deletion_protection to false and apply that change first.", state.ID.ValueInt64()),
+		)
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// resizeNeedsStop reports whether the plan changes the instance type of the
+// VM of state to one it has to be stopped for.
+func resizeNeedsStop(state VirtualMachineResourceModel, plan VirtualMachineResourceModel) bool {
+	return !plan.InstanceType.IsUnknown() && !plan.InstanceType.Equal(state.InstanceType) &&
+		resizeModeBetween(state.InstanceType.ValueString(), plan.InstanceType.ValueString()) == resizeStopped
+}
+
+// vmReader reads VMs.
+type vmReader interface {
+	GetVM(ctx context.Context, id int) (*fakecloud.VirtualMachine, error)
+}
+
+// vmStopped reports whether VM id is stopped.
+func vmStopped(ctx context.Context, client vmReader, id int) (bool, error) {
+	vm, err := client.GetVM(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	return vm.Status == vmStatusStopped, nil
+}
+
+// virtualMachineReplaced reports whether the plan changes an attribute that
+// forces the VM to be replaced.
+func virtualMachineReplaced(state VirtualMachineResourceModel, plan VirtualMachineResourceModel) bool {
+	if !state.Region.Equal(plan.Region) {
+		return true
+	}
+
+	if !state.ImageID.Equal(plan.ImageID) || !state.SSHKeyIDs.Equal(plan.SSHKeyIDs) {
+		return true
+	}
+
+	if !state.UserDataHash.Equal(plan.UserDataHash) && plan.UserDataReplaceOnChange.ValueBool() {
+		return true
+	}
+
+	return !plan.InstanceType.IsUnknown() &&
+		resizeModeBetween(state.InstanceType.ValueString(), plan.InstanceType.ValueString()) == resizeUnsupported
 }
 
 func (r *VirtualMachineResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data VirtualMachineResourceModel
+	var data, config VirtualMachineResourceModel
 
-	// Read Terraform plan data into the model
+	// Read Terraform plan data into the model, write-only attributes are
+	// only available in the configuration.
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userData, err := userDataContent(config.UserData, config.UserDataBase64)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("user_data_base64"), "Invalid User Data", err.Error())
+		return
+	}
+
+	// The default is left unknown by plans made before the provider was
+	// configured, e.g. when its configuration depends on other resources.
+	if data.DeletionProtection.IsUnknown() {
+		data.DeletionProtection = types.BoolValue(r.providerData.deletionProtection)
+	}
+
+	var sshKeyIDs []int
+
+	resp.Diagnostics.Append(data.SSHKeyIDs.ElementsAs(ctx, &sshKeyIDs, false)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
 	// If applicable, this is a great opportunity to initialize any necessary
 	// provider client data and make a call using it.
-	vm, err := r.client.CreateVM(&fakecloud.VirtualMachine{
+	vm, err := client.CreateVM(ctx, &fakecloud.VirtualMachine{
 		Name:         data.Name.ValueString(),
 		InstanceType: data.InstanceType.ValueString(),
+		UserData:     userData.ValueString(),
+		SSHKeyIDs:    sshKeyIDs,
+		ImageID:      int(data.ImageID.ValueInt64()),
 	})
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to create VM", err.Error())
@@ -110,6 +431,10 @@ func (r *VirtualMachineResource) Create(ctx context.Context, req resource.Create
 	// For the purposes of this example code, hardcoding a response value to
 	// save into the Terraform state.
 	data.ID = types.Int64Value(int64(vm.ID))
+	data.Region = regionValue(client.region)
+	data.Project = projectValue(client.project)
+	data.PrivateIP = ipValue(vm.PrivateIP)
+	data.PublicIP = ipValue(vm.PublicIP)
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
@@ -129,9 +454,14 @@ func (r *VirtualMachineResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
 	// If applicable, this is a great opportunity to initialize any necessary
 	// provider client data and make a call using it.
-	vm, err := r.client.GetVM(int(data.ID.ValueInt64()))
+	vm, err := client.GetVM(ctx, int(data.ID.ValueInt64()))
 	if err != nil {
 		resp.Diagnostics.AddError(
 			fmt.Sprintf("Unable to read VM, got error: %s", err), err.Error())
@@ -140,33 +470,205 @@ func (r *VirtualMachineResource) Read(ctx context.Context, req resource.ReadRequ
 
 	data.Name = types.StringValue(vm.Name)
 	data.InstanceType = types.StringValue(vm.InstanceType)
+	data.Region = regionValue(client.region)
+	data.Project = projectValue(client.project)
+	data.UserDataHash = refreshedUserDataHash(data.UserDataHash, vm.UserData)
+	data.PrivateIP = ipValue(vm.PrivateIP)
+	data.PublicIP = ipValue(vm.PublicIP)
+
+	if vm.ImageID != 0 {
+		data.ImageID = types.Int64Value(int64(vm.ImageID))
+	}
+
+	// Keep ssh_key_ids null rather than empty when none are configured.
+	if len(vm.SSHKeyIDs) > 0 || !data.SSHKeyIDs.IsNull() {
+		sshKeyIDs, diags := types.SetValueFrom(ctx, types.Int64Type, vm.SSHKeyIDs)
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		data.SSHKeyIDs = sshKeyIDs
+	}
+
+	// Imported VMs get the defaults.
+	if data.DeletionProtection.IsNull() {
+		data.DeletionProtection = types.BoolValue(r.providerData.deletionProtection)
+	}
+
+	if data.AllowStoppingForUpdate.IsNull() {
+		data.AllowStoppingForUpdate = types.BoolValue(false)
+	}
+
+	if data.UserDataReplaceOnChange.IsNull() {
+		data.UserDataReplaceOnChange = types.BoolValue(true)
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *VirtualMachineResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data VirtualMachineResourceModel
+	var data, state, config VirtualMachineResourceModel
 
-	// Read Terraform plan data into the model
+	// Read Terraform plan, prior state and configuration data into the models
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	err := r.client.UpdateVM(int(data.ID.ValueInt64()), data.Name.ValueString(), data.InstanceType.ValueString())
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultVMUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	id := int(data.ID.ValueInt64())
+
+	// The instance type is changed through the resize workflow below, keep
+	// the current one while renaming.
+	if !data.Name.Equal(state.Name) {
+		err := client.UpdateVM(ctx, id, data.Name.ValueString(), state.InstanceType.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Unable to update VM, got error: %s", err), err.Error())
+			return
+		}
+	}
+
+	// Changed user data only gets here when it is not replacing the VM.
+	if !data.UserDataHash.Equal(state.UserDataHash) {
+		userData, err := userDataContent(config.UserData, config.UserDataBase64)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("user_data_base64"), "Invalid User Data", err.Error())
+			return
+		}
+
+		if err := client.UpdateVMUserData(ctx, id, userData.ValueString()); err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Unable to update VM user data, got error: %s", err), err.Error())
+			return
+		}
+	}
+
+	if !data.InstanceType.Equal(state.InstanceType) {
+		err := r.resize(ctx, client, id, state.InstanceType.ValueString(), data.InstanceType.ValueString(), data.AllowStoppingForUpdate.ValueBool())
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Unable to resize VM, got error: %s", err), err.Error())
+			return
+		}
+	}
+
+	// The public address is not kept across updates, read it back.
+	vm, err := client.GetVM(ctx, id)
 	if err != nil {
-		resp.Diagnostics.AddError(fmt.Sprintf("Unable to update VM, got error: %s", err), err.Error())
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to read VM, got error: %s", err), err.Error())
 		return
 	}
 
+	data.PrivateIP = ipValue(vm.PrivateIP)
+	data.PublicIP = ipValue(vm.PublicIP)
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// resize moves the VM to another instance type. When the change needs the VM
+// to be stopped it is stopped, resized and started again, waiting for each
+// step to complete. A VM that was already stopped is left stopped.
+func (r *VirtualMachineResource) resize(ctx context.Context, client *apiClient, id int, from string, to string, allowStopping bool) error {
+	vm, err := client.GetVM(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	stop := vm.Status != vmStatusStopped && resizeModeBetween(from, to) == resizeStopped
+
+	if stop {
+		if !allowStopping {
+			return fmt.Errorf("changing the instance type from %s to %s requires stopping the VM, set allow_stopping_for_update to true", from, to)
+		}
+
+		tflog.Debug(ctx, "Stopping VM for resize", map[string]interface{}{"id": id, "from": from, "to": to})
+
+		if err := client.StopVM(ctx, id); err != nil {
+			return err
+		}
+
+		if _, err := waitForState(ctx, vmStatus(client, id), []string{vmStatusRunning, vmStatusStopping}, []string{vmStatusStopped}); err != nil {
+			return fmt.Errorf("waiting for VM to stop: %w", err)
+		}
+	}
+
+	if err := client.ResizeVM(ctx, id, to); err != nil {
+		return err
+	}
+
+	// The VM settles back into the status it had before the resize.
+	settled := vmStatusRunning
+	if stop || vm.Status == vmStatusStopped {
+		settled = vmStatusStopped
+	}
+
+	refresh := func(ctx context.Context) (*fakecloud.VirtualMachine, error) {
+		return client.GetVM(ctx, id)
+	}
+
+	if err := waitVMResized(ctx, refresh, vm.Status, to, settled); err != nil {
+		return fmt.Errorf("waiting for VM to be resized: %w", err)
+	}
+
+	if !stop {
+		return nil
+	}
+
+	if err := client.StartVM(ctx, id); err != nil {
+		return err
+	}
+
+	if _, err := waitForState(ctx, vmStatus(client, id), []string{vmStatusStopped, vmStatusStarting}, []string{vmStatusRunning}); err != nil {
+		return fmt.Errorf("waiting for VM to start: %w", err)
+	}
+
+	return nil
+}
+
+// waitVMResized waits for a VM resized from status from to settle into status
+// settled with instance type to. Right after ResizeVM, the API can still
+// report the VM with its prior instance type in the status it settles into,
+// only the new instance type shows that the resize is done.
+func waitVMResized(ctx context.Context, refresh func(ctx context.Context) (*fakecloud.VirtualMachine, error), from string, to string, settled string) error {
+	status := func(vm *fakecloud.VirtualMachine) string { return vm.Status }
+	resized := func(vm *fakecloud.VirtualMachine) bool { return vm.InstanceType == to }
+
+	_, err := waitForChange(ctx, refresh, status, resized, []string{from, vmStatusResizing}, []string{settled})
+
+	return err
+}
+
+// vmStatus returns a refresh function reporting the status of a VM.
+func vmStatus(client *apiClient, id int) stateRefreshFunc {
+	return func(ctx context.Context) (string, error) {
+		vm, err := client.GetVM(ctx, id)
+		if err != nil {
+			return "", err
+		}
+
+		return vm.Status, nil
+	}
+}
+
 func (r *VirtualMachineResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data VirtualMachineResourceModel
 
@@ -177,9 +679,23 @@ func (r *VirtualMachineResource) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
+	if data.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Deletion Protection Enabled",
+			fmt.Sprintf("VM %d cannot be destroyed or replaced while deletion_protection is enabled. "+
+				"Set deletion_protection to false and apply that change first.", data.ID.ValueInt64()),
+		)
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
 	// If applicable, this is a great opportunity to initialize any necessary
 	// provider client data and make a call using it.
-	err := r.client.DeleteVM(int(data.ID.ValueInt64()))
+	err := client.DeleteVM(ctx, int(data.ID.ValueInt64()))
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("Unable to delete VM, got error: %s", err), err.Error())
 		return
@@ -187,5 +703,15 @@ func (r *VirtualMachineResource) Delete(ctx context.Context, req resource.Delete
 }
 
 func (r *VirtualMachineResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importRegional(ctx, req, resp)
+}
+
+// ipValue converts an IP address returned by the API into its Terraform
+// value, null when the address is not assigned.
+func ipValue(address string) types.String {
+	if address == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(address)
 }
@@ -0,0 +1,333 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+// testVirtualMachine returns the model of a VM as stored in state after a
+// create.
+func testVirtualMachine() VirtualMachineResourceModel {
+	return VirtualMachineResourceModel{
+		ID:                      types.Int64Value(42),
+		Name:                    types.StringValue("web"),
+		InstanceType:            types.StringValue("standard.small"),
+		Region:                  types.StringValue("eu-central"),
+		ImageID:                 types.Int64Value(7),
+		PrivateIP:               types.StringValue("10.0.0.2"),
+		DeletionProtection:      types.BoolValue(false),
+		AllowStoppingForUpdate:  types.BoolValue(false),
+		SSHKeyIDs:               types.SetNull(types.Int64Type),
+		UserDataReplaceOnChange: types.BoolValue(true),
+		Timeouts: timeouts.Value{
+			Object: types.ObjectNull(map[string]attr.Type{"update": types.StringType}),
+		},
+	}
+}
+
+// testVirtualMachineState converts a VM model into a state, a null one when
+// data is nil.
+func testVirtualMachineState(t *testing.T, data *VirtualMachineResourceModel) tfsdk.State {
+	t.Helper()
+
+	state := testResourceState(t, &VirtualMachineResource{}, nil)
+
+	if data != nil {
+		if diags := state.Set(context.Background(), data); diags.HasError() {
+			t.Fatalf("unexpected error setting the VM: %v", diags)
+		}
+	}
+
+	return state
+}
+
+func TestVirtualMachineResourceModifyPlanDeletionProtection(t *testing.T) {
+	cases := map[string]struct {
+		protected bool
+		// plan changes the planned VM, nil when the VM is destroyed.
+		plan    func(data *VirtualMachineResourceModel)
+		warning bool
+	}{
+		"destroy protected": {
+			protected: true,
+			warning:   true,
+		},
+		"destroy unprotected": {},
+		"update protected": {
+			protected: true,
+			plan:      func(data *VirtualMachineResourceModel) { data.Name = types.StringValue("api") },
+		},
+		"replace protected": {
+			protected: true,
+			plan:      func(data *VirtualMachineResourceModel) { data.ImageID = types.Int64Value(8) },
+			warning:   true,
+		},
+		"replace unprotected": {
+			plan: func(data *VirtualMachineResourceModel) { data.ImageID = types.Int64Value(8) },
+		},
+		"unsupported resize protected": {
+			protected: true,
+			plan:      func(data *VirtualMachineResourceModel) { data.InstanceType = types.StringValue("arm.small") },
+			warning:   true,
+		},
+		"user data change protected": {
+			protected: true,
+			plan:      func(data *VirtualMachineResourceModel) { data.UserData = types.StringValue("#!/bin/sh") },
+			warning:   true,
+		},
+		"protection lifted in the same plan": {
+			protected: true,
+			plan: func(data *VirtualMachineResourceModel) {
+				data.DeletionProtection = types.BoolValue(false)
+				data.ImageID = types.Int64Value(8)
+			},
+			warning: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			prior := testVirtualMachine()
+			prior.DeletionProtection = types.BoolValue(tc.protected)
+
+			state := testVirtualMachineState(t, &prior)
+			planned := testVirtualMachineState(t, nil)
+
+			if tc.plan != nil {
+				data := prior
+				tc.plan(&data)
+				planned = testVirtualMachineState(t, &data)
+			}
+
+			// The planned VM doubles as its configuration: every attribute
+			// the plan reads from it is set.
+			req := resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: planned.Schema, Raw: planned.Raw},
+				Plan:   tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw},
+				State:  state,
+			}
+			resp := resource.ModifyPlanResponse{Plan: req.Plan}
+
+			r := &VirtualMachineResource{providerData: &providerData{region: "eu-central"}}
+			r.ModifyPlan(ctx, req, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			warned := false
+			for _, d := range resp.Diagnostics.Warnings() {
+				warned = warned || d.Summary() == "Deletion Protection Enabled"
+			}
+
+			if warned != tc.warning {
+				t.Errorf("expected deletion protection warning %t, got %t", tc.warning, warned)
+			}
+		})
+	}
+}
+
+func TestVirtualMachineResourceModifyPlanResize(t *testing.T) {
+	cases := map[string]struct {
+		plan func(data *VirtualMachineResourceModel)
+		err  bool
+	}{
+		"live resize": {
+			plan: func(data *VirtualMachineResourceModel) { data.InstanceType = types.StringValue("standard.large") },
+		},
+		"stopping allowed": {
+			plan: func(data *VirtualMachineResourceModel) {
+				data.InstanceType = types.StringValue("compute.large")
+				data.AllowStoppingForUpdate = types.BoolValue(true)
+			},
+		},
+		"replaced anyway": {
+			plan: func(data *VirtualMachineResourceModel) {
+				data.InstanceType = types.StringValue("compute.large")
+				data.ImageID = types.Int64Value(8)
+			},
+		},
+		// The VM status is read to tell whether it has to be stopped, which
+		// fails without an API endpoint.
+		"in place": {
+			plan: func(data *VirtualMachineResourceModel) { data.InstanceType = types.StringValue("compute.large") },
+			err:  true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			prior := testVirtualMachine()
+			data := prior
+			tc.plan(&data)
+
+			planned := testVirtualMachineState(t, &data)
+
+			req := resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: planned.Schema, Raw: planned.Raw},
+				Plan:   tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw},
+				State:  testVirtualMachineState(t, &prior),
+			}
+			resp := resource.ModifyPlanResponse{Plan: req.Plan}
+
+			r := &VirtualMachineResource{providerData: &providerData{region: "eu-central"}}
+			r.ModifyPlan(ctx, req, &resp)
+
+			if resp.Diagnostics.HasError() != tc.err {
+				t.Errorf("expected error %t, got %v", tc.err, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+// stubVMReader answers GetVM with vm.
+type stubVMReader struct {
+	vm *fakecloud.VirtualMachine
+}
+
+func (c stubVMReader) GetVM(ctx context.Context, id int) (*fakecloud.VirtualMachine, error) {
+	return c.vm, nil
+}
+
+func TestVMStopped(t *testing.T) {
+	cases := map[string]struct {
+		status   string
+		expected bool
+	}{
+		"running": {
+			status: vmStatusRunning,
+		},
+		"stopping": {
+			status: vmStatusStopping,
+		},
+		"stopped": {
+			status:   vmStatusStopped,
+			expected: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := stubVMReader{vm: &fakecloud.VirtualMachine{ID: 42, Status: tc.status}}
+
+			actual, err := vmStopped(context.Background(), client, 42)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if actual != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestVirtualMachineResourceDeleteProtected(t *testing.T) {
+	data := testVirtualMachine()
+	data.DeletionProtection = types.BoolValue(true)
+
+	req := resource.DeleteRequest{State: testVirtualMachineState(t, &data)}
+	resp := resource.DeleteResponse{State: req.State}
+
+	// The resource has no provider data, Delete must refuse before using the
+	// API.
+	(&VirtualMachineResource{}).Delete(context.Background(), req, &resp)
+
+	errs := resp.Diagnostics.Errors()
+	if len(errs) != 1 || errs[0].Summary() != "Deletion Protection Enabled" {
+		t.Fatalf("expected a deletion protection error, got %v", resp.Diagnostics)
+	}
+}
+
+func TestWaitVMResized(t *testing.T) {
+	defer func(interval time.Duration) { waitPollInterval = interval }(waitPollInterval)
+	waitPollInterval = time.Millisecond
+
+	vm := func(status string, instanceType string) *fakecloud.VirtualMachine {
+		return &fakecloud.VirtualMachine{Status: status, InstanceType: instanceType}
+	}
+
+	cases := map[string]struct {
+		vms     []*fakecloud.VirtualMachine
+		from    string
+		settled string
+		polls   int
+		err     bool
+	}{
+		"live resize not started yet": {
+			vms:     []*fakecloud.VirtualMachine{vm("running", "standard.small"), vm("resizing", "standard.small"), vm("running", "standard.large")},
+			from:    "running",
+			settled: "running",
+			polls:   3,
+		},
+		"live resize without resizing status": {
+			vms:     []*fakecloud.VirtualMachine{vm("running", "standard.small"), vm("running", "standard.large")},
+			from:    "running",
+			settled: "running",
+			polls:   2,
+		},
+		"stopped resize": {
+			vms:     []*fakecloud.VirtualMachine{vm("stopped", "standard.small"), vm("resizing", "standard.small"), vm("stopped", "standard.large")},
+			from:    "stopped",
+			settled: "stopped",
+			polls:   3,
+		},
+		"resize never applied": {
+			vms:     []*fakecloud.VirtualMachine{vm("running", "standard.small")},
+			from:    "running",
+			settled: "running",
+			err:     true,
+		},
+		"resize failed": {
+			vms:     []*fakecloud.VirtualMachine{vm("resizing", "standard.small"), vm("error", "standard.small")},
+			from:    "running",
+			settled: "running",
+			err:     true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+
+			polls := 0
+			next := sequenceObjects(tc.vms...)
+			refresh := func(ctx context.Context) (*fakecloud.VirtualMachine, error) {
+				polls++
+				return next(ctx)
+			}
+
+			err := waitVMResized(ctx, refresh, tc.from, "standard.large", tc.settled)
+			if tc.err {
+				if err == nil {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if polls != tc.polls {
+				t.Errorf("expected the wait to return after %d polls, got %d", tc.polls, polls)
+			}
+		})
+	}
+}
@@ -6,8 +6,8 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	fakecloud "github.com/pokgak/fakecloud/sdk"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -21,11 +21,13 @@ func NewVirtualMachinesDataSource() datasource.DataSource {
 }
 
 type virtualMachinesDataSource struct {
-	client *fakecloud.Client
+	providerData *providerData
 }
 
 // virtualMachinesDataSourceModel maps the data source schema data.
 type virtualMachinesDataSourceModel struct {
+	Region          types.String          `tfsdk:"region"`
+	Project         types.String          `tfsdk:"project"`
 	VirtualMachines []virtualMachineModel `tfsdk:"virtual_machines"`
 }
 
@@ -46,23 +48,33 @@ func (d *virtualMachinesDataSource) Configure(_ context.Context, req datasource.
 		return
 	}
 
-	client, ok := req.ProviderData.(*fakecloud.Client)
+	providerData, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *fakecloud.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.providerData = providerData
 }
 
 // Schema defines the schema for the data source.
 func (d *virtualMachinesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
         Attributes: map[string]schema.Attribute{
+            "region": schema.StringAttribute{
+                MarkdownDescription: "Region to list the VMs of. Defaults to the provider region.",
+                Optional:            true,
+                Computed:            true,
+            },
+            "project": schema.StringAttribute{
+                MarkdownDescription: "Project to list the VMs of. Defaults to the provider project.",
+                Optional:            true,
+                Computed:            true,
+            },
             "virtual_machines": schema.ListNestedAttribute{
                 Computed: true,
                 NestedObject: schema.NestedAttributeObject{
@@ -87,7 +99,18 @@ func (d *virtualMachinesDataSource) Schema(_ context.Context, _ datasource.Schem
 func (d *virtualMachinesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state virtualMachinesDataSourceModel
 
-	vms, err := d.client.GetVMs()
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.providerData.client(state.Project.ValueString(), state.Region.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("region"), "Unable to Create Fakecloud API Client", err.Error())
+		return
+	}
+
+	vms, err := client.GetVMs(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Fakecloud VMs",
@@ -96,6 +119,9 @@ func (d *virtualMachinesDataSource) Read(ctx context.Context, req datasource.Rea
 		return
 	}
 
+	state.Region = regionValue(client.region)
+	state.Project = projectValue(client.project)
+
 	// Map response body to model
 	for _, vm := range vms {
 		vmState := virtualMachineModel{
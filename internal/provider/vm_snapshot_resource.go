@@ -0,0 +1,368 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &VMSnapshotResource{}
+var _ resource.ResourceWithImportState = &VMSnapshotResource{}
+var _ resource.ResourceWithModifyPlan = &VMSnapshotResource{}
+
+// Statuses reported by the Fakecloud API for a snapshot.
+const (
+	snapshotStatusCreating  = "creating"
+	snapshotStatusAvailable = "available"
+)
+
+// defaultSnapshotCreateTimeout bounds the creation of a snapshot when the
+// configuration does not set timeouts.create.
+const defaultSnapshotCreateTimeout = 30 * time.Minute
+
+func NewVMSnapshotResource() resource.Resource {
+	return &VMSnapshotResource{}
+}
+
+// VMSnapshotResource defines the resource implementation.
+type VMSnapshotResource struct {
+	providerData *providerData
+}
+
+// VMSnapshotResourceModel describes the resource data model.
+type VMSnapshotResourceModel struct {
+	ID            types.Int64  `tfsdk:"id"`
+	VMID          types.Int64  `tfsdk:"vm_id"`
+	Name          types.String `tfsdk:"name"`
+	RetentionDays types.Int64  `tfsdk:"retention_days"`
+	Region        types.String `tfsdk:"region"`
+	Project       types.String `tfsdk:"project"`
+	Status        types.String `tfsdk:"status"`
+	SizeGB        types.Int64  `tfsdk:"size_gb"`
+	CreatedAt     types.String `tfsdk:"created_at"`
+	ExpiresAt     types.String `tfsdk:"expires_at"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *VMSnapshotResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vm_snapshot"
+}
+
+func (r *VMSnapshotResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Snapshot of the disk of a virtual machine. Creation waits until the snapshot is available.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Snapshot identifier",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"vm_id": schema.Int64Attribute{
+				MarkdownDescription: "Identifier of the VM to snapshot. Changing it forces a new snapshot to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the snapshot",
+				Required:            true,
+			},
+			"retention_days": schema.Int64Attribute{
+				MarkdownDescription: "Number of days after its creation the snapshot is deleted by Fakecloud. Kept until destroyed when unset.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Region of the VM. Defaults to the provider region. Changing it forces a new snapshot to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project of the VM. Defaults to the provider project. Changing it forces a new snapshot to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Status of the snapshot, e.g. `available`.",
+				Computed:            true,
+			},
+			"size_gb": schema.Int64Attribute{
+				MarkdownDescription: "Size of the snapshot in GB.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "Creation time of the snapshot, in RFC 3339 format.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"expires_at": schema.StringAttribute{
+				MarkdownDescription: "Time the snapshot is deleted by Fakecloud, in RFC 3339 format. Null when `retention_days` is unset.",
+				Computed:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+func (r *VMSnapshotResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = providerData
+}
+
+func (r *VMSnapshotResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.planScope(ctx, req.Config, req.State, req.Plan, resp)
+
+	// Nothing more to plan when the snapshot is destroyed or the provider is
+	// not configured yet.
+	if resp.Diagnostics.HasError() || req.Plan.Raw.IsNull() || r.providerData == nil {
+		return
+	}
+
+	var plan, state VMSnapshotResourceModel
+
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only a change of the retention moves the expiry.
+	if !req.State.Raw.IsNull() && plan.RetentionDays.Equal(state.RetentionDays) {
+		plan.ExpiresAt = state.ExpiresAt
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *VMSnapshotResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data VMSnapshotResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultSnapshotCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	snapshot, err := client.CreateSnapshot(ctx, &fakecloud.Snapshot{
+		VMID:          int(data.VMID.ValueInt64()),
+		Name:          data.Name.ValueString(),
+		RetentionDays: int(data.RetentionDays.ValueInt64()),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to create snapshot", err.Error())
+		return
+	}
+
+	data.ID = types.Int64Value(int64(snapshot.ID))
+	saveCreated(ctx, resp, client, data.ID)
+
+	tflog.Trace(ctx, "created a snapshot")
+
+	_, err = waitForState(ctx, r.snapshotStatus(client, snapshot.ID), []string{snapshotStatusCreating}, []string{snapshotStatusAvailable})
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to create snapshot, got error waiting for it to become available: %s", err), err.Error())
+		return
+	}
+
+	snapshot, err = client.GetSnapshot(ctx, snapshot.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to read snapshot, got error: %s", err), err.Error())
+		return
+	}
+
+	r.setSnapshot(&data, client, snapshot)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *VMSnapshotResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data VMSnapshotResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	snapshot, err := client.GetSnapshot(ctx, int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to read snapshot, got error: %s", err), err.Error())
+		return
+	}
+
+	r.setSnapshot(&data, client, snapshot)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *VMSnapshotResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data VMSnapshotResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	id := int(data.ID.ValueInt64())
+
+	err := client.UpdateSnapshot(ctx, id, data.Name.ValueString(), int(data.RetentionDays.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to update snapshot, got error: %s", err), err.Error())
+		return
+	}
+
+	// The expiry is derived from the retention by the API.
+	snapshot, err := client.GetSnapshot(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to read snapshot, got error: %s", err), err.Error())
+		return
+	}
+
+	r.setSnapshot(&data, client, snapshot)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *VMSnapshotResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data VMSnapshotResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.clientFor(data.Project, data.Region, &resp.Diagnostics)
+	if client == nil {
+		return
+	}
+
+	err := client.DeleteSnapshot(ctx, int(data.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to delete snapshot, got error: %s", err), err.Error())
+		return
+	}
+}
+
+func (r *VMSnapshotResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importRegional(ctx, req, resp)
+}
+
+// setSnapshot copies the snapshot returned by the API into the model.
+func (r *VMSnapshotResource) setSnapshot(data *VMSnapshotResourceModel, client *apiClient, snapshot *fakecloud.Snapshot) {
+	data.VMID = types.Int64Value(int64(snapshot.VMID))
+	data.Name = types.StringValue(snapshot.Name)
+	data.Region = regionValue(client.region)
+	data.Project = projectValue(client.project)
+	data.Status = types.StringValue(snapshot.Status)
+	data.SizeGB = types.Int64Value(int64(snapshot.SizeGB))
+	data.CreatedAt = types.StringValue(snapshot.CreatedAt.Format(time.RFC3339))
+
+	data.RetentionDays = types.Int64Null()
+	if snapshot.RetentionDays > 0 {
+		data.RetentionDays = types.Int64Value(int64(snapshot.RetentionDays))
+	}
+
+	data.ExpiresAt = types.StringNull()
+	if snapshot.ExpiresAt != nil {
+		data.ExpiresAt = types.StringValue(snapshot.ExpiresAt.Format(time.RFC3339))
+	}
+}
+
+// snapshotStatus returns a refresh function reporting the status of a
+// snapshot.
+func (r *VMSnapshotResource) snapshotStatus(client *apiClient, id int) stateRefreshFunc {
+	return func(ctx context.Context) (string, error) {
+		snapshot, err := client.GetSnapshot(ctx, id)
+		if err != nil {
+			return "", err
+		}
+
+		return snapshot.Status, nil
+	}
+}
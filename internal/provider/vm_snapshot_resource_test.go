@@ -0,0 +1,150 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+func TestVMSnapshotResourceModifyPlanExpiresAt(t *testing.T) {
+	expiresAt := types.StringValue("2024-03-08T10:00:00Z")
+
+	cases := map[string]struct {
+		// state is the prior snapshot, nil when the snapshot is created.
+		state     map[string]attr.Value
+		retention types.Int64
+		expected  types.String
+	}{
+		"create": {
+			retention: types.Int64Value(7),
+			expected:  types.StringUnknown(),
+		},
+		"retention unchanged": {
+			state:     map[string]attr.Value{"retention_days": types.Int64Value(7), "expires_at": expiresAt},
+			retention: types.Int64Value(7),
+			expected:  expiresAt,
+		},
+		"retention changed": {
+			state:     map[string]attr.Value{"retention_days": types.Int64Value(7), "expires_at": expiresAt},
+			retention: types.Int64Value(14),
+			expected:  types.StringUnknown(),
+		},
+		"retention removed": {
+			state:     map[string]attr.Value{"retention_days": types.Int64Value(7), "expires_at": expiresAt},
+			retention: types.Int64Null(),
+			expected:  types.StringUnknown(),
+		},
+		"retention still unset": {
+			state:     map[string]attr.Value{"retention_days": types.Int64Null(), "expires_at": types.StringNull()},
+			retention: types.Int64Null(),
+			expected:  types.StringNull(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			r := &VMSnapshotResource{providerData: &providerData{region: "eu-central"}}
+
+			configured := map[string]attr.Value{
+				"vm_id":          types.Int64Value(42),
+				"name":           types.StringValue("nightly"),
+				"retention_days": tc.retention,
+			}
+			config := testResourceState(t, r, configured)
+
+			// The framework plans computed attributes unknown until
+			// ModifyPlan decides otherwise.
+			configured["expires_at"] = types.StringUnknown()
+			planned := testResourceState(t, r, configured)
+
+			if tc.state != nil {
+				tc.state["id"] = types.Int64Value(7)
+				tc.state["vm_id"] = types.Int64Value(42)
+				tc.state["name"] = types.StringValue("nightly")
+				tc.state["region"] = types.StringValue("eu-central")
+			}
+
+			req := resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw},
+				Plan:   tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw},
+				State:  testResourceState(t, r, tc.state),
+			}
+			resp := resource.ModifyPlanResponse{Plan: req.Plan}
+
+			r.ModifyPlan(ctx, req, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			var actual types.String
+			if diags := resp.Plan.GetAttribute(ctx, path.Root("expires_at"), &actual); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if !actual.Equal(tc.expected) {
+				t.Errorf("expected expires_at %s, got %s", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestVMSnapshotResourceSetSnapshot(t *testing.T) {
+	createdAt := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	expiresAt := createdAt.AddDate(0, 0, 7)
+
+	cases := map[string]struct {
+		retentionDays int
+		expiresAt     *time.Time
+		expected      [2]attr.Value
+	}{
+		"retention": {
+			retentionDays: 7,
+			expiresAt:     &expiresAt,
+			expected:      [2]attr.Value{types.Int64Value(7), types.StringValue("2024-03-08T10:00:00Z")},
+		},
+		"no retention": {
+			expected: [2]attr.Value{types.Int64Null(), types.StringNull()},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			snapshot := &fakecloud.Snapshot{
+				ID:            7,
+				VMID:          42,
+				Name:          "nightly",
+				Status:        "available",
+				SizeGB:        20,
+				RetentionDays: tc.retentionDays,
+				CreatedAt:     createdAt,
+				ExpiresAt:     tc.expiresAt,
+			}
+
+			var data VMSnapshotResourceModel
+			(&VMSnapshotResource{}).setSnapshot(&data, &apiClient{region: "eu-central"}, snapshot)
+
+			actual := [2]attr.Value{data.RetentionDays, data.ExpiresAt}
+
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("unexpected retention_days and expires_at (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestVMSnapshotResourceImportState(t *testing.T) {
+	testRegionalImportState(t, &VMSnapshotResource{})
+}
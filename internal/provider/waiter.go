@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// waitPollInterval is how often waitForState refreshes the watched object.
+var waitPollInterval = 2 * time.Second
+
+// stateRefreshFunc returns the current status of the object being waited on.
+type stateRefreshFunc func(ctx context.Context) (string, error)
+
+// waitForState polls refresh until it reports one of the target states. It
+// fails as soon as refresh reports a state that is neither pending nor
+// target, or when ctx is done.
+func waitForState(ctx context.Context, refresh stateRefreshFunc, pending []string, target []string) (string, error) {
+	ticker := time.NewTicker(waitPollInterval)
+	defer ticker.Stop()
+
+	for {
+		state, err := refresh(ctx)
+		if err != nil {
+			return state, err
+		}
+
+		tflog.Debug(ctx, "Waiting for state", map[string]interface{}{
+			"state":   state,
+			"pending": pending,
+			"target":  target,
+		})
+
+		if slices.Contains(target, state) {
+			return state, nil
+		}
+
+		if !slices.Contains(pending, state) {
+			return state, fmt.Errorf("unexpected state %q, wanted one of %v", state, target)
+		}
+
+		select {
+		case <-ctx.Done():
+			return state, fmt.Errorf("timeout while waiting for state to become one of %v, last state %q: %w", target, state, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// stateNotApplied is reported by waitForChange in place of a target state
+// while the object does not reflect the change waited on yet.
+const stateNotApplied = "not yet updated"
+
+// waitForChange is waitForState for objects changed asynchronously by the
+// API: right after the change, the API can still report the object unchanged
+// in a target state. refresh fetches the object, status returns its state and
+// applied reports whether it reflects the change, a target state only counts
+// once it does. A nil applied accepts any object. The last object fetched is
+// returned.
+func waitForChange[T any](ctx context.Context, refresh func(ctx context.Context) (T, error), status func(T) string, applied func(T) bool, pending []string, target []string) (T, error) {
+	var object T
+
+	refreshState := func(ctx context.Context) (string, error) {
+		var err error
+
+		object, err = refresh(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		state := status(object)
+		if applied != nil && slices.Contains(target, state) && !applied(object) {
+			return stateNotApplied, nil
+		}
+
+		return state, nil
+	}
+
+	_, err := waitForState(ctx, refreshState, append(slices.Clone(pending), stateNotApplied), target)
+
+	return object, err
+}
@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// sequenceRefresh reports the given states in turn, repeating the last one.
+func sequenceRefresh(states ...string) stateRefreshFunc {
+	return func(ctx context.Context) (string, error) {
+		state := states[0]
+		if len(states) > 1 {
+			states = states[1:]
+		}
+
+		return state, nil
+	}
+}
+
+func TestWaitForState(t *testing.T) {
+	defer func(interval time.Duration) { waitPollInterval = interval }(waitPollInterval)
+	waitPollInterval = time.Millisecond
+
+	pending := []string{"creating"}
+	target := []string{"available"}
+
+	state, err := waitForState(context.Background(), sequenceRefresh("creating", "creating", "available"), pending, target)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if state != "available" {
+		t.Errorf("expected state available, got %s", state)
+	}
+
+	if _, err := waitForState(context.Background(), sequenceRefresh("creating", "error"), pending, target); err == nil {
+		t.Error("expected error on unexpected state, got none")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err = waitForState(ctx, sequenceRefresh("creating"), pending, target)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+// sequenceObjects returns the given objects in turn, repeating the last one.
+func sequenceObjects[T any](objects ...T) func(ctx context.Context) (T, error) {
+	return func(ctx context.Context) (T, error) {
+		object := objects[0]
+		if len(objects) > 1 {
+			objects = objects[1:]
+		}
+
+		return object, nil
+	}
+}
+
+func TestWaitForChange(t *testing.T) {
+	defer func(interval time.Duration) { waitPollInterval = interval }(waitPollInterval)
+	waitPollInterval = time.Millisecond
+
+	type object struct {
+		status  string
+		version string
+	}
+
+	status := func(o object) string { return o.status }
+	upgraded := func(o object) bool { return o.version == "2" }
+
+	cases := map[string]struct {
+		objects  []object
+		applied  func(object) bool
+		expected object
+		err      bool
+	}{
+		"unchanged before pending": {
+			objects:  []object{{"available", "1"}, {"modifying", "1"}, {"available", "2"}},
+			applied:  upgraded,
+			expected: object{"available", "2"},
+		},
+		"unchanged without pending": {
+			objects:  []object{{"available", "1"}, {"available", "1"}, {"available", "2"}},
+			applied:  upgraded,
+			expected: object{"available", "2"},
+		},
+		"already changed": {
+			objects:  []object{{"available", "2"}},
+			applied:  upgraded,
+			expected: object{"available", "2"},
+		},
+		"any object": {
+			objects:  []object{{"modifying", "1"}, {"available", "1"}},
+			expected: object{"available", "1"},
+		},
+		"unexpected state": {
+			objects: []object{{"available", "1"}, {"failed", "1"}},
+			applied: upgraded,
+			err:     true,
+		},
+		"never changed": {
+			objects: []object{{"available", "1"}},
+			applied: upgraded,
+			err:     true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+
+			got, err := waitForChange(ctx, sequenceObjects(tc.objects...), status, tc.applied, []string{"modifying"}, []string{"available"})
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
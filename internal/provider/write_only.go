@@ -0,0 +1,28 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// writeOnlyHash returns the SHA-256 hash stored in state in place of a
+// write-only value, so that a change of the value shows in the plan. It is
+// null when the value is null or empty and unknown while the value is unknown.
+func writeOnlyHash(value types.String) types.String {
+	if value.IsUnknown() {
+		return types.StringUnknown()
+	}
+
+	if value.IsNull() || value.ValueString() == "" {
+		return types.StringNull()
+	}
+
+	sum := sha256.Sum256([]byte(value.ValueString()))
+
+	return types.StringValue(hex.EncodeToString(sum[:]))
+}
@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestWriteOnlyHash(t *testing.T) {
+	cases := map[string]struct {
+		value    types.String
+		expected types.String
+	}{
+		"value": {
+			value:    types.StringValue("s3cr3t"),
+			expected: types.StringValue("4e738ca5563c06cfd0018299933d58db1dd8bf97f6973dc99bf6cdc64b5550bd"),
+		},
+		"null": {
+			value:    types.StringNull(),
+			expected: types.StringNull(),
+		},
+		"empty": {
+			value:    types.StringValue(""),
+			expected: types.StringNull(),
+		},
+		"unknown": {
+			value:    types.StringUnknown(),
+			expected: types.StringUnknown(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if actual := writeOnlyHash(tc.value); !actual.Equal(tc.expected) {
+				t.Errorf("expected %s, got %s", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &zonesDataSource{}
+	_ datasource.DataSourceWithConfigure = &zonesDataSource{}
+)
+
+func NewZonesDataSource() datasource.DataSource {
+	return &zonesDataSource{}
+}
+
+type zonesDataSource struct {
+	providerData *providerData
+}
+
+// zonesDataSourceModel maps the data source schema data.
+type zonesDataSourceModel struct {
+	Region       types.String   `tfsdk:"region"`
+	State        types.String   `tfsdk:"state"`
+	InstanceType types.String   `tfsdk:"instance_type"`
+	Names        []types.String `tfsdk:"names"`
+	Zones        []zoneModel    `tfsdk:"zones"`
+}
+
+// zoneModel maps zone schema data.
+type zoneModel struct {
+	Name          types.String   `tfsdk:"name"`
+	Region        types.String   `tfsdk:"region"`
+	Status        types.String   `tfsdk:"status"`
+	InstanceTypes []types.String `tfsdk:"instance_types"`
+}
+
+func (d *zonesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zones"
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *zonesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.providerData = providerData
+}
+
+// Schema defines the schema for the data source.
+func (d *zonesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the availability zones of a Fakecloud region.",
+
+		Attributes: map[string]schema.Attribute{
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Region to list the zones of. Defaults to the provider region.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "Only list zones with this status, e.g. `available`.",
+				Optional:            true,
+			},
+			"instance_type": schema.StringAttribute{
+				MarkdownDescription: "Only list zones offering this instance type.",
+				Optional:            true,
+			},
+			"names": schema.ListAttribute{
+				MarkdownDescription: "Names of the listed zones, sorted.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"zones": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"region": schema.StringAttribute{
+							Computed: true,
+						},
+						"status": schema.StringAttribute{
+							Computed: true,
+						},
+						"instance_types": schema.ListAttribute{
+							MarkdownDescription: "Instance types offered in the zone.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *zonesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state zonesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.providerData.client("", state.Region.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("region"), "Unable to Create Fakecloud API Client", err.Error())
+		return
+	}
+
+	zones, err := client.GetZones(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Fakecloud Zones",
+			err.Error(),
+		)
+		return
+	}
+
+	state.Region = regionValue(client.region)
+	state.Names = []types.String{}
+	state.Zones = []zoneModel{}
+
+	// Map response body to model
+	for _, zone := range filterZones(zones, client.region, state.State.ValueString(), state.InstanceType.ValueString()) {
+		state.Names = append(state.Names, types.StringValue(zone.Name))
+		state.Zones = append(state.Zones, zoneModel{
+			Name:          types.StringValue(zone.Name),
+			Region:        types.StringValue(zone.Region),
+			Status:        types.StringValue(zone.Status),
+			InstanceTypes: stringValues(zone.InstanceTypes),
+		})
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// filterZones returns the zones of region with the given status and offering
+// the given instance type, sorted by name. An empty status or instance type
+// matches every zone. The region is checked too rather than relying on the
+// regional endpoint to only return its own zones.
+func filterZones(zones []fakecloud.Zone, region string, status string, instanceType string) []fakecloud.Zone {
+	result := make([]fakecloud.Zone, 0, len(zones))
+
+	for _, zone := range zones {
+		if zone.Region != region {
+			continue
+		}
+
+		if status != "" && zone.Status != status {
+			continue
+		}
+
+		if instanceType != "" && !slices.Contains(zone.InstanceTypes, instanceType) {
+			continue
+		}
+
+		result = append(result, zone)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+
+	return result
+}
@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	fakecloud "github.com/pokgak/fakecloud/sdk"
+)
+
+func TestFilterZones(t *testing.T) {
+	zones := []fakecloud.Zone{
+		{Name: "eu-central-b", Region: "eu-central", Status: "available", InstanceTypes: []string{"small", "medium"}},
+		{Name: "eu-central-a", Region: "eu-central", Status: "available", InstanceTypes: []string{"small", "medium", "large"}},
+		{Name: "eu-central-c", Region: "eu-central", Status: "unavailable", InstanceTypes: []string{"small", "large"}},
+		{Name: "us-west-a", Region: "us-west", Status: "available", InstanceTypes: []string{"small", "large"}},
+	}
+
+	cases := map[string]struct {
+		region       string
+		status       string
+		instanceType string
+		expected     []string
+	}{
+		"region sorted": {
+			region:   "eu-central",
+			expected: []string{"eu-central-a", "eu-central-b", "eu-central-c"},
+		},
+		"other region": {
+			region:   "us-west",
+			expected: []string{"us-west-a"},
+		},
+		"state": {
+			region:   "eu-central",
+			status:   "available",
+			expected: []string{"eu-central-a", "eu-central-b"},
+		},
+		"instance type": {
+			region:       "eu-central",
+			instanceType: "large",
+			expected:     []string{"eu-central-a", "eu-central-c"},
+		},
+		"state and instance type": {
+			region:       "eu-central",
+			status:       "available",
+			instanceType: "large",
+			expected:     []string{"eu-central-a"},
+		},
+		"unknown instance type": {
+			region:       "eu-central",
+			instanceType: "xlarge",
+			expected:     []string{},
+		},
+		"unknown region": {
+			region:   "ap-south",
+			expected: []string{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			names := []string{}
+			for _, zone := range filterZones(zones, tc.region, tc.status, tc.instanceType) {
+				names = append(names, zone.Name)
+			}
+
+			if diff := cmp.Diff(tc.expected, names); diff != "" {
+				t.Errorf("unexpected zones (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
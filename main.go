@@ -10,7 +10,7 @@ import (
 
 	"terraform-provider-fakecloud/internal/provider"
 
-	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
 )
 
 // Run "go generate" to format example terraform files and generate the docs for the registry/website
@@ -38,12 +38,25 @@ func main() {
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	opts := providerserver.ServeOpts{
-		Address: "registry.terraform.io/pokgak/fakecloud",
-		Debug:   debug,
+	ctx := context.Background()
+
+	shutdownTracing, err := provider.ConfigureTracing(ctx, version)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	var opts []tf6server.ServeOpt
+
+	if debug {
+		opts = append(opts, tf6server.WithManagedDebug())
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	err = tf6server.Serve("registry.terraform.io/pokgak/fakecloud", provider.NewTracedProtocol6(provider.New(version)()), opts...)
+
+	// Flush the spans of the last RPCs before exiting.
+	if shutdownErr := shutdownTracing(ctx); shutdownErr != nil {
+		log.Println(shutdownErr.Error())
+	}
 
 	if err != nil {
 		log.Fatal(err.Error())